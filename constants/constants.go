@@ -6,6 +6,25 @@ const TrueString = "true"
 //SeedFileName defines the filename for the seed file
 const SeedFileName = "seed.manifest.json"
 
+//SeedYamlFileName defines the filename for a seed file written in YAML
+const SeedYamlFileName = "seed.manifest.yaml"
+
+//SeedYmlFileName defines the alternate filename for a seed file written in YAML
+const SeedYmlFileName = "seed.manifest.yml"
+
+//SeedManifestLabel defines the image label the seed manifest is stored under
+const SeedManifestLabel = "com.ngageoint.seed.manifest"
+
+//SeedCompressedManifestLabel defines the image label a gzip compressed, base64 encoded seed
+//manifest is stored under when the manifest is too large for the plain label
+const SeedCompressedManifestLabel = "com.ngageoint.seed.manifest.gz"
+
+//SupportedSeedVersions defines the version constraint a manifest's seedVersion must satisfy
+const SupportedSeedVersions = "^1.0.0"
+
+//SeedOutputsFileName defines the filename a seed job writes its json outputs to within the output directory
+const SeedOutputsFileName = "seed.outputs.json"
+
 //DefaultRegistry defines the default registry address to use when searching for images
 const DefaultRegistry = "https://hub.docker.com/"
 
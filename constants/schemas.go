@@ -0,0 +1,380 @@
+package constants
+
+//SeedManifestSchema defines the JSON schema for a seed.manifest.json conforming to seed 1.0.0
+const SeedManifestSchema = `{
+  "$schema": "http://json-schema.org/draft-04/schema#",
+  "id": "https://ngageoint.github.io/seed/schema/seed.manifest.schema.json",
+  "title": "Seed v1.0.0",
+  "description": "Standard for packaging discrete units of work in Docker images",
+  "type": "object",
+  "required": ["seedVersion", "job"],
+  "properties": {
+    "seedVersion": {
+      "$ref": "#/definitions/semver"
+    },
+    "job": {
+      "type": "object",
+      "required": ["name", "jobVersion", "packageVersion", "title", "description", "maintainer", "timeout"],
+      "properties": {
+        "name": {
+          "type": "string",
+          "pattern": "^[a-z0-9_-]+$"
+        },
+        "jobVersion": {
+          "$ref": "#/definitions/semver"
+        },
+        "packageVersion": {
+          "$ref": "#/definitions/semver"
+        },
+        "title": {
+          "type": "string"
+        },
+        "description": {
+          "type": "string"
+        },
+        "tags": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "maintainer": {
+          "$ref": "#/definitions/maintainer"
+        },
+        "timeout": {
+          "type": "integer",
+          "minimum": 0
+        },
+        "interface": {
+          "$ref": "#/definitions/interface"
+        },
+        "resources": {
+          "$ref": "#/definitions/resources"
+        },
+        "errors": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/error"
+          }
+        }
+      }
+    }
+  },
+  "definitions": {
+    "semver": {
+      "type": "string",
+      "pattern": "^(0|[1-9][0-9]*)\\.(0|[1-9][0-9]*)\\.(0|[1-9][0-9]*)(-[0-9A-Za-z-]+(\\.[0-9A-Za-z-]+)*)?(\\+[0-9A-Za-z-]+(\\.[0-9A-Za-z-]+)*)?$"
+    },
+    "variableName": {
+      "type": "string",
+      "pattern": "^[a-zA-Z0-9_-]+$"
+    },
+    "maintainer": {
+      "type": "object",
+      "required": ["name", "email"],
+      "properties": {
+        "name": {
+          "type": "string"
+        },
+        "organization": {
+          "type": "string"
+        },
+        "email": {
+          "type": "string"
+        },
+        "url": {
+          "type": "string"
+        },
+        "phone": {
+          "type": "string"
+        }
+      }
+    },
+    "interface": {
+      "type": "object",
+      "properties": {
+        "command": {
+          "type": "string"
+        },
+        "inputs": {
+          "type": "object",
+          "properties": {
+            "files": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/inputFile"
+              }
+            },
+            "json": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/inputJson"
+              }
+            }
+          }
+        },
+        "outputs": {
+          "type": "object",
+          "properties": {
+            "files": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/outputFile"
+              }
+            },
+            "json": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/outputJson"
+              }
+            }
+          }
+        },
+        "mounts": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/mount"
+          }
+        },
+        "settings": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/setting"
+          }
+        }
+      }
+    },
+    "inputFile": {
+      "type": "object",
+      "required": ["name"],
+      "properties": {
+        "name": {
+          "$ref": "#/definitions/variableName"
+        },
+        "mediaTypes": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "multiple": {
+          "type": "boolean"
+        },
+        "partial": {
+          "type": "boolean"
+        },
+        "required": {
+          "type": "boolean"
+        }
+      }
+    },
+    "inputJson": {
+      "type": "object",
+      "required": ["name", "type"],
+      "properties": {
+        "name": {
+          "$ref": "#/definitions/variableName"
+        },
+        "type": {
+          "$ref": "#/definitions/jsonType"
+        },
+        "required": {
+          "type": "boolean"
+        }
+      }
+    },
+    "outputFile": {
+      "type": "object",
+      "required": ["name", "pattern"],
+      "properties": {
+        "name": {
+          "$ref": "#/definitions/variableName"
+        },
+        "mediaType": {
+          "type": "string"
+        },
+        "multiple": {
+          "type": "boolean"
+        },
+        "pattern": {
+          "type": "string"
+        },
+        "required": {
+          "type": "boolean"
+        }
+      }
+    },
+    "outputJson": {
+      "type": "object",
+      "required": ["name", "type"],
+      "properties": {
+        "name": {
+          "$ref": "#/definitions/variableName"
+        },
+        "key": {
+          "type": "string"
+        },
+        "type": {
+          "$ref": "#/definitions/jsonType"
+        },
+        "required": {
+          "type": "boolean"
+        }
+      }
+    },
+    "jsonType": {
+      "type": "string",
+      "enum": ["array", "boolean", "integer", "number", "object", "string"]
+    },
+    "mount": {
+      "type": "object",
+      "required": ["name", "path"],
+      "properties": {
+        "name": {
+          "$ref": "#/definitions/variableName"
+        },
+        "path": {
+          "type": "string",
+          "pattern": "^/"
+        },
+        "mode": {
+          "type": "string",
+          "enum": ["ro", "rw"]
+        }
+      }
+    },
+    "setting": {
+      "type": "object",
+      "required": ["name"],
+      "properties": {
+        "name": {
+          "$ref": "#/definitions/variableName"
+        },
+        "secret": {
+          "type": "boolean"
+        }
+      }
+    },
+    "resources": {
+      "type": "object",
+      "properties": {
+        "scalar": {
+          "type": "array",
+          "items": {
+            "type": "object",
+            "required": ["name", "value"],
+            "properties": {
+              "name": {
+                "type": "string"
+              },
+              "value": {
+                "type": "number",
+                "minimum": 0
+              },
+              "inputMultiplier": {
+                "type": "number",
+                "minimum": 0
+              }
+            }
+          }
+        }
+      }
+    },
+    "error": {
+      "type": "object",
+      "required": ["code", "name"],
+      "properties": {
+        "code": {
+          "type": "integer"
+        },
+        "name": {
+          "type": "string"
+        },
+        "title": {
+          "type": "string"
+        },
+        "description": {
+          "type": "string"
+        },
+        "category": {
+          "type": "string",
+          "enum": ["job", "data"]
+        }
+      }
+    }
+  }
+}`
+
+//SeedMetadataSchema defines the JSON schema for a seed output metadata file (GeoJSON side-car)
+const SeedMetadataSchema = `{
+  "$schema": "http://json-schema.org/draft-04/schema#",
+  "id": "https://ngageoint.github.io/seed/schema/seed.metadata.schema.json",
+  "title": "Seed Metadata v1.0.0",
+  "description": "GeoJSON side-car metadata for files produced by a seed job",
+  "type": "object",
+  "required": ["type"],
+  "properties": {
+    "type": {
+      "type": "string",
+      "enum": ["Feature"]
+    },
+    "geometry": {
+      "oneOf": [
+        {
+          "type": "null"
+        },
+        {
+          "type": "object",
+          "required": ["type"],
+          "properties": {
+            "type": {
+              "type": "string",
+              "enum": ["Point", "MultiPoint", "LineString", "MultiLineString", "Polygon", "MultiPolygon", "GeometryCollection"]
+            }
+          }
+        }
+      ]
+    },
+    "properties": {
+      "type": ["object", "null"],
+      "properties": {
+        "dataStarted": {
+          "$ref": "#/definitions/timestamp"
+        },
+        "dataEnded": {
+          "$ref": "#/definitions/timestamp"
+        },
+        "dataTypes": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "sourceStarted": {
+          "$ref": "#/definitions/timestamp"
+        },
+        "sourceEnded": {
+          "$ref": "#/definitions/timestamp"
+        },
+        "sourceSensorClass": {
+          "type": "string"
+        },
+        "sourceSensor": {
+          "type": "string"
+        },
+        "sourceCollection": {
+          "type": "string"
+        },
+        "sourceTask": {
+          "type": "string"
+        }
+      }
+    }
+  },
+  "definitions": {
+    "timestamp": {
+      "type": "string",
+      "format": "date-time"
+    }
+  }
+}`
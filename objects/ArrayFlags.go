@@ -2,6 +2,9 @@ package objects
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
+	"strings"
 )
 
 //ArrayFlags defines the values of a flag that may be used multiple times
@@ -26,3 +29,18 @@ func (flags *ArrayFlags) Set(value string) error {
 	*flags = append(*flags, value)
 	return nil
 }
+
+//KeyValues parses each KEY=VAL flag into a map of keys to values. Only the first equals sign
+// separates the key from the value so values may contain equals signs.
+func (flags *ArrayFlags) KeyValues() (map[string]string, error) {
+	values := make(map[string]string)
+	for _, f := range *flags {
+		parts := strings.SplitN(f, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			msg := fmt.Sprintf("ERROR: Invalid value %s. Expected KEY=VAL", f)
+			return nil, errors.New(msg)
+		}
+		values[parts[0]] = parts[1]
+	}
+	return values, nil
+}
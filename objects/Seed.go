@@ -3,13 +3,12 @@ package objects
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
-	"os/exec"
-	"strings"
 
-	"github.com/ngageoint/seed-common/constants"
 	"github.com/ngageoint/seed-common/util"
 )
 
@@ -196,125 +195,68 @@ func (o *ErrorMap) UnmarshalJSON(b []byte) error {
 
 //GetManifestLabel returns the seed.manifest.json as LABEL
 //  com.ngageoint.seed.manifest contents
+// This is intended for CLI use only; it exits the process on failure. Library code should
+// use LoadManifestLabel instead.
 func GetManifestLabel(seedFileName string) string {
-	// read the seed.manifest.json into a string
-	seedbytes, err := ioutil.ReadFile(seedFileName)
-	if err != nil {
-		util.PrintUtil("ERROR: Error reading %s. %s\n", seedFileName,
-			err.Error())
-		os.Exit(1)
-	}
-	var seedbuff bytes.Buffer
-	json.Compact(&seedbuff, seedbytes)
-	seedbytes, err = json.Marshal(seedbuff.String())
-	if err != nil {
-		util.PrintUtil("ERROR: Error marshalling seed manifest. %s\n",
-			err.Error())
-	}
-
-	// Escape forward slashes and dollar signs
-	seed := string(seedbytes)
-	seed = strings.Replace(seed, "$", "\\$", -1)
-	seed = strings.Replace(seed, "/", "\\/", -1)
+	seed, err := LoadManifestLabel(seedFileName)
+	exitOnError(err)
 
 	return seed
 }
 
 //SeedFromImageLabel returns seed parsed from the docker image LABEL
+// This is intended for CLI use only; it exits the process on failure. Library code should
+// use LoadSeedFromImageLabel instead.
 func SeedFromImageLabel(imageName string) Seed {
-	cmdStr := "inspect -f '{{index .Config.Labels \"com.ngageoint.seed.manifest\"}}'" + imageName
 	util.PrintUtil(
 		"INFO: Retrieving seed manifest from %s LABEL=com.ngageoint.seed.manifest\n",
 		imageName)
 
-	inspectCommand := exec.Command("docker", "inspect", "-f",
-		"'{{index .Config.Labels \"com.ngageoint.seed.manifest\"}}'", imageName)
-
-	errPipe, errr := inspectCommand.StderrPipe()
-	if errr != nil {
-		util.PrintUtil(
-			"ERROR: error attaching to docker inspect command stderr. %s\n",
-			errr.Error())
-	}
-
-	// Attach stdout pipe
-	outPipe, errr := inspectCommand.StdoutPipe()
-	if errr != nil {
-		util.PrintUtil(
-			"ERROR: error attaching to docker inspect command stdout. %s\n",
-			errr.Error())
-	}
-
-	// Run docker inspect
-	if err := inspectCommand.Start(); err != nil {
-		util.PrintUtil("ERROR: error executing docker %s. %s\n", cmdStr,
-			err.Error())
-	}
-
-	// Print out any std out
-	seedBytes, err := ioutil.ReadAll(outPipe)
-	if err != nil {
-		util.PrintUtil("ERROR: Error retrieving docker %s stdout.\n%s\n",
-			cmdStr, err.Error())
-	}
-
-	// check for errors on stderr
-	slurperr, _ := ioutil.ReadAll(errPipe)
-	if string(slurperr) != "" {
-		util.PrintUtil("ERROR: Error executing docker %s:\n%s\n",
-			cmdStr, string(slurperr))
-		util.PrintUtil("Exiting seed...\n")
-		os.Exit(1)
-	}
-
-	// un-escape special characters
-	seedStr := string(seedBytes)
-	seedStr = util.UnescapeManifestLabel(seedStr)
-
-	seed := &Seed{}
-
-	err = json.Unmarshal([]byte(seedStr), &seed)
-	if err != nil {
-		util.PrintUtil("ERROR: Error unmarshalling seed: %s\n", err.Error())
-	}
+	seed, err := LoadSeedFromImageLabel(imageName)
+	exitOnError(err)
 
-	return *seed
+	return seed
 }
 
 //SeedFromManifestFile returns seed struct parsed from seed file
+// This is intended for CLI use only; it exits the process on failure. Library code should
+// use LoadSeedFromManifestFile instead.
 func SeedFromManifestFile(seedFileName string) Seed {
+	seed, err := LoadSeedFromManifestFile(seedFileName)
+	if _, ok := err.(*ManifestParseError); ok {
+		util.PrintUtil("ERROR: A valid seed manifest must be present at %s.\n", seedFileName)
+	}
+	exitOnError(err)
 
-	// Open and parse seed file into struct
-	seedFile, err := os.Open(seedFileName)
+	return seed
+}
+
+//exitOnError prints the given error and exits seed if it is non-nil
+func exitOnError(err error) {
 	if err != nil {
-		util.PrintUtil("ERROR: Error opening %s. Error received is: %s\n",
-			seedFileName, err.Error())
-		util.PrintUtil("Exiting seed...\n")
-		os.Exit(1)
-	}
-	jsonParser := json.NewDecoder(seedFile)
-	var seed Seed
-	if err = jsonParser.Decode(&seed); err != nil {
-		util.PrintUtil(
-			"ERROR: A valid %s must be present in the working directory. Error parsing %s.\nError received is: %s\n",
-			constants.SeedFileName, seedFileName, err.Error())
+		util.PrintUtil("%s\n", err.Error())
 		util.PrintUtil("Exiting seed...\n")
 		os.Exit(1)
 	}
-
-	return seed
 }
 
 //SeedFromManifestString returns seed struct parsed from seed manifest string
+// Legacy manifests are migrated to the current seed version; fields that could not be
+// migrated are reported through PrintUtil.
 func SeedFromManifestString(manifest string) (Seed, error) {
-	seed := &Seed{}
-
-	err := json.Unmarshal([]byte(manifest), &seed)
+	seed, report, err := DecodeManifest([]byte(manifest))
 	if err != nil {
 		util.PrintUtil("ERROR: Error unmarshalling seed: %s\n", err.Error())
 	}
 
-	return *seed, err
+	if report != nil {
+		util.PrintUtil("WARN: Migrated seed manifest from version %s to %s\n", report.FromVersion, report.ToVersion)
+		for _, issue := range report.Unmapped {
+			util.PrintUtil("WARN: Unable to migrate %s\n", issue.String())
+		}
+	}
+
+	return seed, err
 }
 
 //BuildImageName extracts the Docker Image name from the seed.json
@@ -352,26 +294,25 @@ func GetSeedManifestFromBlob(blob io.ReadCloser) (string, error) {
 		return "", err
 	}
 
-	label := blobStruct.Config.Labels["com.ngageoint.seed.manifest"]
-
-	seedStr := util.UnescapeManifestLabel(label)
+	seedStr, _, err := util.ManifestFromLabels(blobStruct.Config.Labels)
 
 	return seedStr, err
 }
 
+//GetImageNameFromManifest returns the docker image name of the seed manifest given by name, or
+// found in the directory when no name is given. Errors are returned rather than printed.
 func GetImageNameFromManifest(manifest, directory string) (string, error) {
 	seedFileName := ""
 	if manifest != "." && manifest != "" {
 		seedFileName = util.GetFullPath(manifest, directory)
 		if _, err := os.Stat(seedFileName); os.IsNotExist(err) {
-			util.PrintUtil("ERROR: Seed manifest not found. %s\n", err.Error())
-			return "", err
+			msg := fmt.Sprintf("ERROR: Seed manifest not found. %s", err.Error())
+			return "", errors.New(msg)
 		}
 	} else {
 		temp, err := util.SeedFileName(directory)
 		seedFileName = temp
 		if err != nil {
-			util.PrintUtil("ERROR: Seed manifest not found. Error=%s\n", err.Error())
 			return "", err
 		}
 	}
@@ -379,7 +320,10 @@ func GetImageNameFromManifest(manifest, directory string) (string, error) {
 	util.PrintUtil("INFO: Found manifest: %s\n", seedFileName)
 
 	// retrieve seed from seed manifest
-	seed := SeedFromManifestFile(seedFileName)
+	seed, err := LoadSeedFromManifestFile(seedFileName)
+	if err != nil {
+		return "", err
+	}
 
 	// Retrieve docker image name
 	image := BuildImageName(&seed)
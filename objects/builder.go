@@ -0,0 +1,337 @@
+package objects
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ngageoint/seed-common/util"
+)
+
+//JsonTypes defines the valid types of json inputs and outputs
+var JsonTypes = []string{"array", "boolean", "integer", "number", "object", "string"}
+
+//BuildError is returned by SeedBuilder.Build with every problem found while building the seed
+type BuildError struct {
+	Problems []string
+}
+
+func (e *BuildError) Error() string {
+	return fmt.Sprintf("ERROR: Invalid seed manifest:\n%s", strings.Join(e.Problems, "\n"))
+}
+
+//fieldOptions holds the optional attributes of interface members, scalars and errors
+type fieldOptions struct {
+	optional        bool
+	multiple        bool
+	partial         bool
+	secret          bool
+	readWrite       bool
+	mediaTypes      []string
+	key             string
+	category        string
+	title           string
+	description     string
+	inputMultiplier float64
+	applied         []string
+}
+
+func (o *fieldOptions) set(name string) {
+	o.applied = append(o.applied, name)
+}
+
+//FieldOption sets an optional attribute of a member added to a SeedBuilder
+type FieldOption func(*fieldOptions)
+
+//Optional marks an input or output as not required
+func Optional() FieldOption {
+	return func(o *fieldOptions) { o.optional = true; o.set("Optional") }
+}
+
+//Multiple marks an input or output file as accepting or producing multiple files
+func Multiple() FieldOption {
+	return func(o *fieldOptions) { o.multiple = true; o.set("Multiple") }
+}
+
+//Partial marks an input file as only partially read by the job
+func Partial() FieldOption {
+	return func(o *fieldOptions) { o.partial = true; o.set("Partial") }
+}
+
+//Secret marks a setting as secret
+func Secret() FieldOption {
+	return func(o *fieldOptions) { o.secret = true; o.set("Secret") }
+}
+
+//ReadWrite mounts a directory read-write instead of read-only
+func ReadWrite() FieldOption {
+	return func(o *fieldOptions) { o.readWrite = true; o.set("ReadWrite") }
+}
+
+//MediaTypes sets the media types of an input file, or the media type of an output file
+func MediaTypes(mediaTypes ...string) FieldOption {
+	return func(o *fieldOptions) { o.mediaTypes = mediaTypes; o.set("MediaTypes") }
+}
+
+//Key sets the key a json output is read from in seed.outputs.json
+func Key(key string) FieldOption {
+	return func(o *fieldOptions) { o.key = key; o.set("Key") }
+}
+
+//Category sets the category of an error
+func Category(category string) FieldOption {
+	return func(o *fieldOptions) { o.category = category; o.set("Category") }
+}
+
+//Describe sets the title and description of an error
+func Describe(title, description string) FieldOption {
+	return func(o *fieldOptions) { o.title, o.description = title, description; o.set("Describe") }
+}
+
+//InputMultiplier sets the amount of a scalar resource required per MiB of input
+func InputMultiplier(multiplier float64) FieldOption {
+	return func(o *fieldOptions) { o.inputMultiplier = multiplier; o.set("InputMultiplier") }
+}
+
+//SeedBuilder constructs a Seed one member at a time, applying the same defaults as the custom
+// UnmarshalJSON methods (inputs and outputs are required, mounts are read-only and errors are job
+// errors) and recording problems as it goes. Build returns the seed or every problem found.
+//
+//	seed, err := NewSeedBuilder("my-job", "0.1.0").
+//		Title("My job").
+//		InputFile("INPUT_FILE", MediaTypes("image/tiff")).
+//		Setting("DB_PASS", Secret()).
+//		Build()
+type SeedBuilder struct {
+	seed     Seed
+	problems []string
+}
+
+//NewSeedBuilder starts a seed for the given job name and version with the current seed version and
+// a package version of 1.0.0
+func NewSeedBuilder(name, jobVersion string) *SeedBuilder {
+	b := &SeedBuilder{}
+	b.seed.SeedVersion = CurrentSeedVersion
+	b.seed.Job.Name = name
+	b.seed.Job.PackageVersion = "1.0.0"
+	b.seed.Job.Resources.Scalar = []Scalar{}
+	return b.JobVersion(jobVersion)
+}
+
+func (b *SeedBuilder) problem(format string, args ...interface{}) {
+	b.problems = append(b.problems, fmt.Sprintf(format, args...))
+}
+
+//options applies the given options and records a problem for any that do not apply to the member
+func (b *SeedBuilder) options(member string, allowed []string, opts []FieldOption) fieldOptions {
+	o := fieldOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	for _, name := range o.applied {
+		if !util.ContainsString(allowed, name) {
+			b.problem("%s: option %s does not apply", member, name)
+		}
+	}
+	return o
+}
+
+func (b *SeedBuilder) checkJsonType(jsonType, path string) {
+	if !util.ContainsString(JsonTypes, jsonType) {
+		b.problem("%s: unknown type %q; expected one of %v", path, jsonType, JsonTypes)
+	}
+}
+
+//JobVersion sets the version of the job's algorithm
+func (b *SeedBuilder) JobVersion(version string) *SeedBuilder {
+	b.seed.Job.JobVersion = version
+	return b
+}
+
+//PackageVersion sets the version of the job's packaging
+func (b *SeedBuilder) PackageVersion(version string) *SeedBuilder {
+	b.seed.Job.PackageVersion = version
+	return b
+}
+
+//Title sets the job title
+func (b *SeedBuilder) Title(title string) *SeedBuilder {
+	b.seed.Job.Title = title
+	return b
+}
+
+//Description sets the job description
+func (b *SeedBuilder) Description(description string) *SeedBuilder {
+	b.seed.Job.Description = description
+	return b
+}
+
+//Tags adds tags to the job
+func (b *SeedBuilder) Tags(tags ...string) *SeedBuilder {
+	b.seed.Job.Tags = append(b.seed.Job.Tags, tags...)
+	return b
+}
+
+//Maintainer sets the job maintainer
+func (b *SeedBuilder) Maintainer(maintainer Maintainer) *SeedBuilder {
+	b.seed.Job.Maintainer = maintainer
+	return b
+}
+
+//Timeout sets the job timeout in seconds
+func (b *SeedBuilder) Timeout(seconds int) *SeedBuilder {
+	if seconds <= 0 {
+		b.problem("job.timeout: %d must be greater than 0", seconds)
+	}
+	b.seed.Job.Timeout = seconds
+	return b
+}
+
+//Command sets the job command
+func (b *SeedBuilder) Command(command string) *SeedBuilder {
+	b.seed.Job.Interface.Command = command
+	return b
+}
+
+//InputFile adds a required input file. Accepts the Optional, Multiple, Partial and MediaTypes options.
+func (b *SeedBuilder) InputFile(name string, opts ...FieldOption) *SeedBuilder {
+	path := fmt.Sprintf("job.interface.inputs.files[%d]", len(b.seed.Job.Interface.Inputs.Files))
+	o := b.options(path, []string{"Optional", "Multiple", "Partial", "MediaTypes"}, opts)
+
+	b.seed.Job.Interface.Inputs.Files = append(b.seed.Job.Interface.Inputs.Files, InFile{
+		Name:       name,
+		MediaTypes: o.mediaTypes,
+		Multiple:   o.multiple,
+		Partial:    o.partial,
+		Required:   !o.optional,
+	})
+	return b
+}
+
+//InputJson adds a required json input of the given type. Accepts the Optional option.
+func (b *SeedBuilder) InputJson(name, jsonType string, opts ...FieldOption) *SeedBuilder {
+	path := fmt.Sprintf("job.interface.inputs.json[%d]", len(b.seed.Job.Interface.Inputs.Json))
+	b.checkJsonType(jsonType, path+".type")
+	o := b.options(path, []string{"Optional"}, opts)
+
+	b.seed.Job.Interface.Inputs.Json = append(b.seed.Job.Interface.Inputs.Json, InJson{
+		Name:     name,
+		Type:     jsonType,
+		Required: !o.optional,
+	})
+	return b
+}
+
+//OutputFile adds a required output file matching the given glob pattern. Accepts the Optional,
+// Multiple and MediaTypes options; only a single media type may be given.
+func (b *SeedBuilder) OutputFile(name, pattern string, opts ...FieldOption) *SeedBuilder {
+	path := fmt.Sprintf("job.interface.outputs.files[%d]", len(b.seed.Job.Interface.Outputs.Files))
+	if pattern == "" {
+		b.problem("%s.pattern: pattern is empty", path)
+	}
+	o := b.options(path, []string{"Optional", "Multiple", "MediaTypes"}, opts)
+	if len(o.mediaTypes) > 1 {
+		b.problem("%s.mediaType: an output file has a single media type", path)
+	}
+
+	out := OutFile{Name: name, Pattern: pattern, Multiple: o.multiple, Required: !o.optional}
+	if len(o.mediaTypes) > 0 {
+		out.MediaType = o.mediaTypes[0]
+	}
+	b.seed.Job.Interface.Outputs.Files = append(b.seed.Job.Interface.Outputs.Files, out)
+	return b
+}
+
+//OutputJson adds a required json output of the given type. Accepts the Optional and Key options.
+func (b *SeedBuilder) OutputJson(name, jsonType string, opts ...FieldOption) *SeedBuilder {
+	path := fmt.Sprintf("job.interface.outputs.json[%d]", len(b.seed.Job.Interface.Outputs.JSON))
+	b.checkJsonType(jsonType, path+".type")
+	o := b.options(path, []string{"Optional", "Key"}, opts)
+
+	b.seed.Job.Interface.Outputs.JSON = append(b.seed.Job.Interface.Outputs.JSON, OutJson{
+		Name:     name,
+		Key:      o.key,
+		Type:     jsonType,
+		Required: !o.optional,
+	})
+	return b
+}
+
+//Mount adds a read-only mount at the given absolute container path. Accepts the ReadWrite option.
+func (b *SeedBuilder) Mount(name, containerPath string, opts ...FieldOption) *SeedBuilder {
+	path := fmt.Sprintf("job.interface.mounts[%d]", len(b.seed.Job.Interface.Mounts))
+	if !strings.HasPrefix(containerPath, "/") {
+		b.problem("%s.path: %q is not an absolute path", path, containerPath)
+	}
+	o := b.options(path, []string{"ReadWrite"}, opts)
+
+	mount := Mount{Name: name, Path: containerPath, Mode: "ro"}
+	if o.readWrite {
+		mount.Mode = "rw"
+	}
+	b.seed.Job.Interface.Mounts = append(b.seed.Job.Interface.Mounts, mount)
+	return b
+}
+
+//Setting adds a setting. Accepts the Secret option.
+func (b *SeedBuilder) Setting(name string, opts ...FieldOption) *SeedBuilder {
+	path := fmt.Sprintf("job.interface.settings[%d]", len(b.seed.Job.Interface.Settings))
+	o := b.options(path, []string{"Secret"}, opts)
+
+	b.seed.Job.Interface.Settings = append(b.seed.Job.Interface.Settings, Setting{Name: name, Secret: o.secret})
+	return b
+}
+
+//Scalar adds a scalar resource requirement. Accepts the InputMultiplier option.
+func (b *SeedBuilder) Scalar(name string, value float64, opts ...FieldOption) *SeedBuilder {
+	path := fmt.Sprintf("job.resources.scalar[%d]", len(b.seed.Job.Resources.Scalar))
+	if name == "" {
+		b.problem("%s.name: name is empty", path)
+	}
+	for _, s := range b.seed.Job.Resources.Scalar {
+		if s.Name == name {
+			b.problem("%s.name: %s is already declared", path, name)
+		}
+	}
+	if value < 0 {
+		b.problem("%s.value: %v must not be negative", path, value)
+	}
+	o := b.options(path, []string{"InputMultiplier"}, opts)
+	if o.inputMultiplier < 0 {
+		b.problem("%s.inputMultiplier: %v must not be negative", path, o.inputMultiplier)
+	}
+
+	b.seed.Job.Resources.Scalar = append(b.seed.Job.Resources.Scalar, Scalar{
+		Name:            name,
+		Value:           value,
+		InputMultiplier: o.inputMultiplier,
+	})
+	return b
+}
+
+//Error maps an exit code to a job error. Accepts the Category and Describe options.
+func (b *SeedBuilder) Error(code int, name string, opts ...FieldOption) *SeedBuilder {
+	path := fmt.Sprintf("job.errors[%d]", len(b.seed.Job.Errors))
+	if name == "" {
+		b.problem("%s.name: name is empty", path)
+	}
+	o := b.options(path, []string{"Category", "Describe"}, opts)
+
+	errorMap := ErrorMap{Code: code, Name: name, Title: o.title, Description: o.description, Category: ErrorCategoryJob}
+	if o.category != "" {
+		errorMap.Category = o.category
+	}
+	b.seed.Job.Errors = append(b.seed.Job.Errors, errorMap)
+	return b
+}
+
+//Build validates the seed with the manifest schema and Lint and returns it. If any problems were
+// found while building or validating, a *BuildError listing all of them is returned instead.
+// Names, versions, error codes and categories are only checked by Lint so each problem is reported
+// once.
+func (b *SeedBuilder) Build() (Seed, error) {
+	problems := append(append([]string{}, b.problems...), validateSeed(&b.seed)...)
+	if len(problems) > 0 {
+		return Seed{}, &BuildError{Problems: problems}
+	}
+	return b.seed, nil
+}
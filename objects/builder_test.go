@@ -0,0 +1,135 @@
+package objects
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ngageoint/seed-common/constants"
+	"github.com/ngageoint/seed-common/util"
+)
+
+func TestSeedBuilder(t *testing.T) {
+	seed, err := NewSeedBuilder("my-job", "0.1.0").
+		Title("My first job").
+		Description("Reads an HDF5 file and outputs two TIFF images, a CSV and manifest containing cell_count").
+		Tags("hdf5", "tiff", "csv", "image processing").
+		Maintainer(Maintainer{Name: "John Doe", Organization: "E-corp", Email: "jdoe@example.com",
+			Url: "http://www.example.com", Phone: "666-555-4321"}).
+		Timeout(3600).
+		Command("${INPUT_FILE} ${OUTPUT_DIR}").
+		InputFile("INPUT_FILE", MediaTypes("image/x-hdf5-image")).
+		OutputFile("output_file_tiffs", "outfile*.tif", MediaTypes("image/tiff"), Multiple()).
+		OutputFile("output_file_csv", "outfile*.csv", MediaTypes("text/csv"), Optional()).
+		OutputJson("cell_count", "integer", Key("cellCount")).
+		Mount("MOUNT_PATH", "/the/container/path").
+		Setting("DB_HOST").
+		Setting("DB_PASS", Secret()).
+		Scalar("cpus", 10).
+		Scalar("mem", 10240).
+		Scalar("sharedMem", 0).
+		Scalar("disk", 10, InputMultiplier(4)).
+		Error(1, "data-issue", Describe("Data Issue discovered", "There was a problem with input data"), Category("data")).
+		Error(2, "missing-mount", Describe("Missing mount", "Expected mount point not available at run time")).
+		Error(3, "missing-setting", Describe("Missing setting", "Expected setting not defined in environment")).
+		Error(4, "missing-env", Describe("Missing environment", "Expected environment not provided")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build returned an error: %v", err)
+	}
+
+	expected, err := LoadSeedFromManifestFile("../testdata/yaml/seed.manifest.yaml")
+	if err != nil {
+		t.Fatalf("Error loading expected manifest: %v", err)
+	}
+	expected.Job.Interface.Settings = append(expected.Job.Interface.Settings, Setting{Name: "DB_PASS", Secret: true})
+	if !reflect.DeepEqual(seed.Job.Interface, expected.Job.Interface) {
+		t.Errorf("Build returned interface %v, expected %v", seed.Job.Interface, expected.Job.Interface)
+	}
+	if !reflect.DeepEqual(seed.Job.Resources, expected.Job.Resources) {
+		t.Errorf("Build returned resources %v, expected %v", seed.Job.Resources, expected.Job.Resources)
+	}
+	errors := []ErrorMap{
+		{1, "data-issue", "Data Issue discovered", "There was a problem with input data", "data"},
+		{2, "missing-mount", "Missing mount", "Expected mount point not available at run time", "job"},
+		{3, "missing-setting", "Missing setting", "Expected setting not defined in environment", "job"},
+		{4, "missing-env", "Missing environment", "Expected environment not provided", "job"},
+	}
+	if !reflect.DeepEqual(seed.Job.Errors, errors) {
+		t.Errorf("Build returned errors %v, expected %v", seed.Job.Errors, errors)
+	}
+
+	manifest, _ := json.Marshal(seed)
+	if schemaErrors, err := util.Validate(manifest, constants.SchemaManifest); err != nil || len(schemaErrors) != 0 {
+		t.Errorf("Built seed failed validation: %v, %v", schemaErrors, err)
+	}
+}
+
+func TestSeedBuilderErrors(t *testing.T) {
+	valid := func() *SeedBuilder {
+		return NewSeedBuilder("job", "1.0.0").Title("Job").Description("A job").Timeout(60).
+			Maintainer(Maintainer{Name: "Jane Doe", Email: "jdoe@example.com"})
+	}
+
+	cases := []struct {
+		builder  *SeedBuilder
+		problems []string
+	}{
+		{valid(), nil},
+		{NewSeedBuilder("job", "1.0.0"), []string{"job.title: title is required",
+			"job.description: description is required", "job.timeout: timeout is required"}},
+		{valid().JobVersion("1.0").PackageVersion("x"), []string{
+			`job.jobVersion: "1.0" is not a valid semantic version`,
+			`job.packageVersion: "x" is not a valid semantic version`}},
+		{valid().InputFile("INPUT").Setting("input").InputJson("", "string"), []string{
+			"job.interface.settings[0].name: input collides with job.interface.inputs.files[0].name after normalization to INPUT",
+			"job.interface.inputs.json[0].name: name is empty"}},
+		{valid().Setting("OUTPUT_DIR").Setting("ALLOCATED_GPUS"), []string{
+			"job.interface.settings[0].name: OUTPUT_DIR is a reserved variable name",
+			"job.interface.settings[1].name: ALLOCATED_GPUS is a reserved variable name"}},
+		{valid().InputJson("COUNT", "int").OutputFile("OUT", "", MediaTypes("a", "b")), []string{
+			`job.interface.inputs.json[0].type: unknown type "int"; expected one of [array boolean integer number object string]`,
+			"job.interface.outputs.files[0].pattern: pattern is empty",
+			"job.interface.outputs.files[0].mediaType: an output file has a single media type"}},
+		{valid().Mount("DATA", "relative", Secret()), []string{
+			`job.interface.mounts[0].path: "relative" is not an absolute path`,
+			"job.interface.mounts[0]: option Secret does not apply"}},
+		{valid().Error(1, "a").Error(1, "b", Category("system")), []string{
+			"job.errors[1].code: error code 1 is already declared at job.errors[0].code",
+			`job.errors[1].category: unknown error category "system"; expected one of [job data]`}},
+		{valid().Scalar("cpus", -1).Scalar("cpus", 1, InputMultiplier(-2)).Timeout(0), []string{
+			"job.resources.scalar[0].value: -1 must not be negative",
+			"job.resources.scalar[1].name: cpus is already declared",
+			"job.resources.scalar[1].inputMultiplier: -2 must not be negative",
+			"job.timeout: 0 must be greater than 0"}},
+	}
+
+	for i, c := range cases {
+		_, err := c.builder.Build()
+		if err == nil {
+			if c.problems != nil {
+				t.Errorf("case %d: Build did not return an error, expected %v", i, c.problems)
+			}
+			continue
+		}
+		buildErr, ok := err.(*BuildError)
+		if !ok {
+			t.Errorf("case %d: Build returned %T, expected *BuildError", i, err)
+			continue
+		}
+		for _, problem := range c.problems {
+			if !util.ContainsString(buildErr.Problems, problem) {
+				t.Errorf("case %d: Build returned problems %v\n expected %v", i, buildErr.Problems, problem)
+			}
+		}
+		for j, problem := range buildErr.Problems {
+			if util.ContainsString(buildErr.Problems[j+1:], problem) {
+				t.Errorf("case %d: Build reported %v more than once", i, problem)
+			}
+		}
+		if !strings.HasPrefix(err.Error(), "ERROR: Invalid seed manifest:") {
+			t.Errorf("case %d: Build returned error %v", i, err)
+		}
+	}
+}
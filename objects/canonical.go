@@ -0,0 +1,98 @@
+package objects
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+//DigestPrefix defines the algorithm prefix of a seed manifest digest
+const DigestPrefix = "sha256:"
+
+//Normalized returns a copy of the seed with default values filled in the same way the custom
+// UnmarshalJSON methods apply them, so that equivalent seeds compare and encode identically
+func (seed Seed) Normalized() Seed {
+	job := seed.Job
+
+	job.Tags = append([]string(nil), job.Tags...)
+	if len(job.Tags) == 0 {
+		job.Tags = nil
+	}
+
+	iface := job.Interface
+	iface.Inputs.Files = append([]InFile(nil), iface.Inputs.Files...)
+	for i := range iface.Inputs.Files {
+		if len(iface.Inputs.Files[i].MediaTypes) == 0 {
+			iface.Inputs.Files[i].MediaTypes = nil
+		}
+	}
+	iface.Inputs.Json = append([]InJson(nil), iface.Inputs.Json...)
+	iface.Outputs.Files = append([]OutFile(nil), iface.Outputs.Files...)
+	iface.Outputs.JSON = append([]OutJson(nil), iface.Outputs.JSON...)
+	iface.Mounts = append([]Mount(nil), iface.Mounts...)
+	for i := range iface.Mounts {
+		if iface.Mounts[i].Mode == "" {
+			iface.Mounts[i].Mode = "ro"
+		}
+	}
+	iface.Settings = append([]Setting(nil), iface.Settings...)
+	job.Interface = iface
+
+	job.Resources.Scalar = append([]Scalar(nil), job.Resources.Scalar...)
+
+	job.Errors = append([]ErrorMap(nil), job.Errors...)
+	for i := range job.Errors {
+		if job.Errors[i].Category == "" {
+			job.Errors[i].Category = ErrorCategoryJob
+		}
+	}
+
+	seed.Job = job
+	return seed
+}
+
+//CanonicalJSON encodes the normalized seed as compact JSON with object keys in sorted order.
+// Semantically identical manifests produce identical bytes regardless of the original key order,
+// whitespace or omitted default values.
+func (seed Seed) CanonicalJSON() ([]byte, error) {
+	structured, err := json.Marshal(seed.Normalized())
+	if err != nil {
+		return nil, err
+	}
+
+	// round trip through a generic value; maps are encoded with sorted keys
+	var generic interface{}
+	if err = json.Unmarshal(structured, &generic); err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err = encoder.Encode(generic); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), nil
+}
+
+//Digest returns the sha256 digest of the seed's canonical JSON, i.e. sha256:<hex>
+func (seed Seed) Digest() (string, error) {
+	canonical, err := seed.CanonicalJSON()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(canonical)
+	return DigestPrefix + hex.EncodeToString(sum[:]), nil
+}
+
+//ManifestDigest returns the digest of the given seed manifest string, such as the manifest
+// returned by RepositoryRegistry.GetImageManifest
+func ManifestDigest(manifest string) (string, error) {
+	seed, _, err := DecodeManifest([]byte(manifest))
+	if err != nil {
+		return "", err
+	}
+	return seed.Digest()
+}
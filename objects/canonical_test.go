@@ -0,0 +1,73 @@
+package objects
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	cases := []struct {
+		manifest  string
+		canonical string
+	}{
+		{`{"job": {"name": "a", "interface": {"mounts": [{"path": "/p", "name": "M"}]}}, "seedVersion": "1.0.0"}`,
+			`{"job":{"interface":{"inputs":{},"mounts":[{"mode":"ro","name":"M","path":"/p"}],"outputs":{}},"jobVersion":"","maintainer":{"email":"","name":""},"name":"a","packageVersion":"","resources":{"scalar":null}},"seedVersion":"1.0.0"}`},
+		{`{"seedVersion": "1.0.0", "job": {"name": "<a&b>", "tags": [], "errors": [{"code": 1, "name": "e"}],
+			"interface": {"inputs": {"files": [{"name": "IN", "mediaTypes": []}]}}}}`,
+			`{"job":{"errors":[{"category":"job","code":1,"name":"e"}],"interface":{"inputs":{"files":[{"multiple":false,"name":"IN","partial":false,"required":true}]},"outputs":{}},"jobVersion":"","maintainer":{"email":"","name":""},"name":"<a&b>","packageVersion":"","resources":{"scalar":null}},"seedVersion":"1.0.0"}`},
+	}
+
+	for _, c := range cases {
+		seed, err := SeedFromManifestString(c.manifest)
+		if err != nil {
+			t.Fatalf("Error parsing manifest %v: %v", c.manifest, err)
+		}
+		canonical, err := seed.CanonicalJSON()
+		if err != nil || string(canonical) != c.canonical {
+			t.Errorf("CanonicalJSON(%q) returned %s, %v\n expected %s", c.manifest, canonical, err, c.canonical)
+		}
+	}
+}
+
+func TestDigest(t *testing.T) {
+	complete, _ := ioutil.ReadFile("../testdata/complete/seed.manifest.json")
+
+	// same manifest with different whitespace, key order and explicit defaults removed
+	reordered := strings.Replace(string(complete), `"multiple": false,
+            "partial": false,
+            "required": true`, `"required": true`, 1)
+	reordered = strings.Replace(reordered, `"seedVersion": "1.0.0",`, "", 1)
+	reordered = strings.Replace(reordered, `"timeout": 3600,`, `"timeout": 3600, "tags": ["hdf5", "tiff", "csv", "image processing"],`, 1)
+	reordered = strings.TrimSuffix(strings.TrimSpace(reordered), "}") + `, "seedVersion": "1.0.0"}`
+
+	changed := strings.Replace(string(complete), `"timeout": 3600`, `"timeout": 60`, 1)
+
+	base, err := ManifestDigest(string(complete))
+	if err != nil || !strings.HasPrefix(base, DigestPrefix) || len(base) != len(DigestPrefix)+64 {
+		t.Fatalf("ManifestDigest returned %v, %v, expected a sha256 digest", base, err)
+	}
+
+	cases := []struct {
+		manifest string
+		same     bool
+	}{
+		{string(complete), true},
+		{reordered, true},
+		{changed, false},
+	}
+
+	for _, c := range cases {
+		digest, err := ManifestDigest(c.manifest)
+		if err != nil {
+			t.Errorf("ManifestDigest returned an error: %v", err)
+		}
+		if (digest == base) != c.same {
+			t.Errorf("ManifestDigest returned %v, expected same as %v: %v", digest, base, c.same)
+		}
+	}
+
+	if _, err := ManifestDigest("{"); err == nil {
+		t.Errorf("ManifestDigest did not return an error for an invalid manifest")
+	}
+}
@@ -0,0 +1,268 @@
+package objects
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ngageoint/seed-common/util"
+)
+
+//OutputDirVariable defines the environment variable containing the job output directory
+const OutputDirVariable = "OUTPUT_DIR"
+
+//MultipleMode defines how input files with Multiple set are provided to the command
+type MultipleMode int
+
+const (
+	//MultipleAsDirectory resolves the input variable to the directory containing the files
+	MultipleAsDirectory MultipleMode = iota
+
+	//MultipleAsList expands a standalone reference to the input into one argument per file
+	MultipleAsList
+)
+
+//CommandValues holds the concrete values used to expand an Interface.Command. All maps are
+// keyed by the name declared in the manifest.
+type CommandValues struct {
+	Files     map[string][]string
+	Json      map[string]interface{}
+	Settings  map[string]string
+	Mounts    map[string]string //optional override of the container path declared for a mount
+	OutputDir string
+	Env       map[string]string //additional variables, i.e. ALLOCATED_*
+	Multiple  MultipleMode
+}
+
+//commandVariable is a resolved variable; list variables hold one entry per file
+type commandVariable struct {
+	values []string
+	list   bool
+}
+
+//commandSegment is part of a command argument; quoted single segments are not expanded
+type commandSegment struct {
+	text   string
+	expand bool
+}
+
+//ExpandCommand resolves the ${VAR} and $VAR references in the seed's Interface.Command using the
+// given values and returns the resulting argument list. Any referenced variables that are not
+// declared by the manifest (or reserved by the spec) are returned as undeclared.
+func ExpandCommand(seed *Seed, values CommandValues) ([]string, []string) {
+	vars := commandVariables(seed, values)
+
+	undeclared := []string{}
+	lookup := func(name string) string {
+		v, ok := vars[name]
+		if !ok {
+			if !util.ContainsString(undeclared, name) {
+				undeclared = append(undeclared, name)
+			}
+			return ""
+		}
+		return strings.Join(v.values, " ")
+	}
+
+	args := []string{}
+	for _, token := range splitCommand(seed.Job.Interface.Command) {
+		// a bare reference to a multiple input expands to one argument per file
+		if len(token) == 1 && token[0].expand {
+			if name, ok := bareReference(token[0].text); ok {
+				if v, ok := vars[name]; ok && v.list {
+					args = append(args, v.values...)
+					continue
+				}
+			}
+		}
+
+		arg := ""
+		quoted := false
+		for _, segment := range token {
+			if segment.expand {
+				arg += os.Expand(segment.text, lookup)
+			} else {
+				arg += segment.text
+				quoted = true
+			}
+		}
+
+		// unquoted references to empty values are dropped like in a shell
+		if arg == "" && !quoted {
+			continue
+		}
+		args = append(args, arg)
+	}
+
+	sort.Strings(undeclared)
+	return args, undeclared
+}
+
+//commandVariables returns the variables available to the seed's command, keyed by normalized name
+func commandVariables(seed *Seed, values CommandValues) map[string]commandVariable {
+	vars := make(map[string]commandVariable)
+	iface := seed.Job.Interface
+
+	for _, f := range iface.Inputs.Files {
+		files := values.Files[f.Name]
+		v := commandVariable{values: files}
+		if f.Multiple {
+			if values.Multiple == MultipleAsList {
+				v.list = true
+			} else if len(files) > 0 {
+				v.values = []string{commonDirectory(files)}
+			}
+		} else if len(files) > 1 {
+			v.values = files[:1]
+		}
+		vars[util.GetNormalizedVariable(f.Name)] = v
+	}
+
+	for _, j := range iface.Inputs.Json {
+		v := commandVariable{}
+		if value, ok := values.Json[j.Name]; ok {
+			v.values = []string{jsonString(value)}
+		}
+		vars[util.GetNormalizedVariable(j.Name)] = v
+	}
+
+	for _, m := range iface.Mounts {
+		path := m.Path
+		if override, ok := values.Mounts[m.Name]; ok {
+			path = override
+		}
+		vars[util.GetNormalizedVariable(m.Name)] = commandVariable{values: []string{path}}
+	}
+
+	for _, s := range iface.Settings {
+		v := commandVariable{}
+		if value, ok := values.Settings[s.Name]; ok {
+			v.values = []string{value}
+		}
+		vars[util.GetNormalizedVariable(s.Name)] = v
+	}
+
+	for _, name := range AllocatedVariables(seed) {
+		vars[name] = commandVariable{}
+	}
+	for name, value := range values.Env {
+		vars[name] = commandVariable{values: []string{value}}
+	}
+
+	vars[OutputDirVariable] = commandVariable{values: []string{values.OutputDir}}
+
+	return vars
+}
+
+//jsonString converts a json input value into its command line form
+func jsonString(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(b)
+}
+
+//commonDirectory returns the deepest directory containing all of the given files
+func commonDirectory(files []string) string {
+	dir := filepath.Dir(files[0])
+	for _, f := range files[1:] {
+		for !strings.HasPrefix(f, dir+string(filepath.Separator)) && dir != filepath.Dir(dir) {
+			dir = filepath.Dir(dir)
+		}
+	}
+	return dir
+}
+
+//bareReference checks if the text is exactly one $VAR or ${VAR} reference
+func bareReference(text string) (string, bool) {
+	name := ""
+	if strings.HasPrefix(text, "${") && strings.HasSuffix(text, "}") {
+		name = text[2 : len(text)-1]
+	} else if strings.HasPrefix(text, "$") {
+		name = text[1:]
+	}
+	if name == "" || strings.IndexFunc(name, func(r rune) bool {
+		return !(r == '_' || r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	}) >= 0 {
+		return "", false
+	}
+	return name, true
+}
+
+//splitCommand splits a command into arguments on unquoted whitespace. Single quoted text is kept
+// literally, double quoted text is grouped but still expanded.
+func splitCommand(command string) [][]commandSegment {
+	tokens := [][]commandSegment{}
+	token := []commandSegment{}
+	current := ""
+	inToken := false
+	var quote rune
+
+	flush := func(expand bool) {
+		if current != "" || !expand {
+			token = append(token, commandSegment{text: current, expand: expand})
+		}
+		current = ""
+	}
+
+	runes := []rune(command)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				flush(false)
+				quote = 0
+			} else {
+				current += string(r)
+			}
+		case quote == '"':
+			if r == '"' {
+				flush(true)
+				// mark the token as quoted so an empty expansion is kept
+				token = append(token, commandSegment{})
+				quote = 0
+			} else if r == '\\' && i+1 < len(runes) && strings.ContainsRune(`"\$`, runes[i+1]) {
+				i++
+				flush(true)
+				current = string(runes[i])
+				flush(false)
+			} else {
+				current += string(r)
+			}
+		case r == '\'' || r == '"':
+			flush(true)
+			quote = r
+			inToken = true
+		case r == '\\' && i+1 < len(runes):
+			i++
+			flush(true)
+			current = string(runes[i])
+			flush(false)
+			inToken = true
+		case r == ' ' || r == '\t' || r == '\n':
+			flush(true)
+			if inToken {
+				tokens = append(tokens, token)
+			}
+			token = []commandSegment{}
+			inToken = false
+		default:
+			current += string(r)
+			inToken = true
+		}
+	}
+	flush(true)
+	if inToken {
+		tokens = append(tokens, token)
+	}
+
+	return tokens
+}
@@ -0,0 +1,61 @@
+package objects
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestExpandCommand(t *testing.T) {
+	seed := Seed{Job: Job{
+		Interface: Interface{
+			Inputs: Inputs{
+				Files: []InFile{{Name: "INPUT_FILE"}, {Name: "images", Multiple: true}},
+				Json:  []InJson{{Name: "threshold"}, {Name: "options"}},
+			},
+			Mounts:   []Mount{{Name: "MOUNT_PATH", Path: "/the/container/path"}},
+			Settings: []Setting{{Name: "DB_HOST"}},
+		},
+		Resources: Resources{Scalar: []Scalar{{Name: "cpus", Value: 1}}},
+	}}
+
+	values := CommandValues{
+		Files: map[string][]string{
+			"INPUT_FILE": {"/in/file.h5"},
+			"images":     {"/in/images/a.tif", "/in/images/b.tif"},
+		},
+		Json:      map[string]interface{}{"threshold": 0.5, "options": map[string]interface{}{"fast": true}},
+		Settings:  map[string]string{"DB_HOST": "db.example.com"},
+		OutputDir: "/out",
+		Env:       map[string]string{"ALLOCATED_CPUS": "1.0"},
+	}
+
+	cases := []struct {
+		command    string
+		multiple   MultipleMode
+		args       []string
+		undeclared string
+	}{
+		{"${INPUT_FILE} ${OUTPUT_DIR}", MultipleAsDirectory, []string{"/in/file.h5", "/out"}, "[]"},
+		{"-i $IMAGES -o $OUTPUT_DIR/result", MultipleAsDirectory, []string{"-i", "/in/images", "-o", "/out/result"}, "[]"},
+		{"-i $IMAGES -o $OUTPUT_DIR", MultipleAsList, []string{"-i", "/in/images/a.tif", "/in/images/b.tif", "-o", "/out"}, "[]"},
+		{"-i \"$IMAGES\"", MultipleAsList, []string{"-i", "/in/images/a.tif /in/images/b.tif"}, "[]"},
+		{"--threshold=${THRESHOLD} --options '${OPTIONS}' ${OPTIONS}", MultipleAsDirectory, []string{"--threshold=0.5", "--options", "${OPTIONS}", `{"fast":true}`}, "[]"},
+		{"${MOUNT_PATH} ${DB_HOST} ${ALLOCATED_CPUS} ${ALLOCATED_MEM} x", MultipleAsDirectory, []string{"/the/container/path", "db.example.com", "1.0", "x"}, "[]"},
+		{"${UNKNOWN} $OTHER \"\" ${INPUT_FILE}", MultipleAsDirectory, []string{"", "/in/file.h5"}, "[OTHER UNKNOWN]"},
+		{"with\\ space \"a $DB_HOST b\"", MultipleAsDirectory, []string{"with space", "a db.example.com b"}, "[]"},
+	}
+
+	for _, c := range cases {
+		seed.Job.Interface.Command = c.command
+		values.Multiple = c.multiple
+		args, undeclared := ExpandCommand(&seed, values)
+
+		if !reflect.DeepEqual(args, c.args) {
+			t.Errorf("ExpandCommand(%q) returned %q, expected %q", c.command, args, c.args)
+		}
+		if fmt.Sprintf("%s", undeclared) != c.undeclared {
+			t.Errorf("ExpandCommand(%q) returned undeclared %s, expected %s", c.command, undeclared, c.undeclared)
+		}
+	}
+}
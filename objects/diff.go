@@ -0,0 +1,433 @@
+package objects
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ngageoint/seed-common/util"
+)
+
+//ChangeKind defines how an element changed between two seeds
+type ChangeKind string
+
+const (
+	//ChangeAdded elements only exist in the new seed
+	ChangeAdded ChangeKind = "added"
+
+	//ChangeRemoved elements only exist in the old seed
+	ChangeRemoved ChangeKind = "removed"
+
+	//ChangeModified elements exist in both seeds with different values
+	ChangeModified ChangeKind = "changed"
+)
+
+//VersionBump defines which version of a seed must be increased for a set of changes
+type VersionBump int
+
+const (
+	//BumpNone no changes were found
+	BumpNone VersionBump = iota
+
+	//BumpPackage only the PackageVersion must be increased
+	BumpPackage
+
+	//BumpJob the JobVersion must be increased
+	BumpJob
+)
+
+//String returns the name of the version field that must be bumped
+func (b VersionBump) String() string {
+	switch b {
+	case BumpPackage:
+		return "packageVersion"
+	case BumpJob:
+		return "jobVersion"
+	}
+	return "none"
+}
+
+//Change describes a single difference between two seeds
+type Change struct {
+	Section  string
+	Name     string
+	Kind     ChangeKind
+	Detail   string
+	Breaking bool
+}
+
+//String formats the change as a single line
+func (c Change) String() string {
+	impact := "compatible"
+	if c.Breaking {
+		impact = "BREAKING"
+	}
+	str := fmt.Sprintf("[%s] %s %s %s", impact, c.Section, c.Name, c.Kind)
+	if c.Detail != "" {
+		str += ": " + c.Detail
+	}
+	return str
+}
+
+//SeedDiff describes the differences between two seeds
+type SeedDiff struct {
+	Changes     []Change
+	Recommended VersionBump
+	//Satisfied is true if the new seed already increases the recommended version
+	Satisfied bool
+}
+
+//Breaking checks if any of the changes are breaking
+func (d *SeedDiff) Breaking() bool {
+	for _, c := range d.Changes {
+		if c.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+//String returns a human readable report of the differences
+func (d *SeedDiff) String() string {
+	var buffer bytes.Buffer
+	if len(d.Changes) == 0 {
+		buffer.WriteString("No interface changes found.\n")
+	}
+	for _, c := range d.Changes {
+		buffer.WriteString(c.String())
+		buffer.WriteString("\n")
+	}
+	if d.Recommended != BumpNone {
+		status := "NOT bumped"
+		if d.Satisfied {
+			status = "bumped"
+		}
+		buffer.WriteString(fmt.Sprintf("Recommendation: %s must be bumped (%s)\n", d.Recommended, status))
+	}
+	return buffer.String()
+}
+
+func (d *SeedDiff) add(section, name string, kind ChangeKind, breaking bool, format string, args ...interface{}) {
+	d.Changes = append(d.Changes, Change{Section: section, Name: name, Kind: kind,
+		Detail: fmt.Sprintf(format, args...), Breaking: breaking})
+}
+
+//DiffSeeds compares the interface, resources and errors of two seeds, classifies each change as
+// breaking or compatible for callers of the job and recommends which version must be bumped.
+// Breaking changes require a greater JobVersion; compatible changes a greater JobVersion or the
+// same JobVersion with a greater PackageVersion.
+func DiffSeeds(old, updated *Seed) SeedDiff {
+	d := SeedDiff{}
+	oldIface, newIface := old.Job.Interface, updated.Job.Interface
+
+	if oldIface.Command != newIface.Command {
+		d.add("interface", "command", ChangeModified, false, "%q -> %q", oldIface.Command, newIface.Command)
+	}
+
+	diffInputFiles(&d, oldIface.Inputs.Files, newIface.Inputs.Files)
+	diffInputJson(&d, oldIface.Inputs.Json, newIface.Inputs.Json)
+	diffOutputFiles(&d, oldIface.Outputs.Files, newIface.Outputs.Files)
+	diffOutputJson(&d, oldIface.Outputs.JSON, newIface.Outputs.JSON)
+	diffMounts(&d, oldIface.Mounts, newIface.Mounts)
+	diffSettings(&d, oldIface.Settings, newIface.Settings)
+	diffResources(&d, old.Job.Resources.Scalar, updated.Job.Resources.Scalar)
+	diffErrors(&d, old.Job.Errors, updated.Job.Errors)
+
+	jobBump, jobValid := compareVersions(old.Job.JobVersion, updated.Job.JobVersion)
+	if d.Breaking() {
+		d.Recommended = BumpJob
+		d.Satisfied = jobValid && jobBump > 0
+	} else if len(d.Changes) > 0 {
+		packageBump, packageValid := compareVersions(old.Job.PackageVersion, updated.Job.PackageVersion)
+		d.Recommended = BumpPackage
+		d.Satisfied = jobValid && (jobBump > 0 || jobBump == 0 && packageValid && packageBump > 0)
+	}
+
+	return d
+}
+
+//compareVersions compares the updated semantic version to the old one as util.Version.Compare does.
+// False is returned if either version is invalid.
+func compareVersions(old, updated string) (int, bool) {
+	oldVersion, err := util.ParseVersion(old)
+	if err != nil {
+		return 0, false
+	}
+	newVersion, err := util.ParseVersion(updated)
+	if err != nil {
+		return 0, false
+	}
+	return newVersion.Compare(oldVersion), true
+}
+
+//sectionDiff compares the items of one section of two seeds by key, in the style of sort.Slice.
+// Items only in the old seed are classified by removed, items only in the new seed by added, and
+// changed reports the differences between items in both through modified.
+type sectionDiff struct {
+	section        string
+	oldLen, newLen int
+	oldKey, newKey func(i int) string
+	removed        func(i int) (breaking bool, detail string)
+	added          func(j int) (breaking bool, detail string)
+	changed        func(i, j int, modified func(breaking bool, format string, args ...interface{}))
+}
+
+//apply adds the changes of the section to the diff, ordered by kind and then key
+func (s sectionDiff) apply(d *SeedDiff) {
+	oldIndex, newIndex := map[string]int{}, map[string]int{}
+	for i := 0; i < s.oldLen; i++ {
+		oldIndex[s.oldKey(i)] = i
+	}
+	for j := 0; j < s.newLen; j++ {
+		newIndex[s.newKey(j)] = j
+	}
+
+	removed, added, common := []string{}, []string{}, []string{}
+	for key := range oldIndex {
+		if _, ok := newIndex[key]; ok {
+			common = append(common, key)
+		} else {
+			removed = append(removed, key)
+		}
+	}
+	for key := range newIndex {
+		if _, ok := oldIndex[key]; !ok {
+			added = append(added, key)
+		}
+	}
+	sort.Strings(removed)
+	sort.Strings(added)
+	sort.Strings(common)
+
+	for _, key := range removed {
+		breaking, detail := s.removed(oldIndex[key])
+		d.add(s.section, key, ChangeRemoved, breaking, "%s", detail)
+	}
+	for _, key := range added {
+		breaking, detail := s.added(newIndex[key])
+		d.add(s.section, key, ChangeAdded, breaking, "%s", detail)
+	}
+	for _, key := range common {
+		s.changed(oldIndex[key], newIndex[key], func(breaking bool, format string, args ...interface{}) {
+			d.add(s.section, key, ChangeModified, breaking, format, args...)
+		})
+	}
+}
+
+//inputAdded classifies a new input; only new required inputs break callers
+func inputAdded(required bool) (bool, string) {
+	if required {
+		return true, "new required input"
+	}
+	return false, "new optional input"
+}
+
+func diffInputFiles(d *SeedDiff, oldFiles, newFiles []InFile) {
+	sectionDiff{
+		section: "inputs.files",
+		oldLen:  len(oldFiles),
+		newLen:  len(newFiles),
+		oldKey:  func(i int) string { return oldFiles[i].Name },
+		newKey:  func(j int) string { return newFiles[j].Name },
+		removed: func(i int) (bool, string) { return true, "input no longer accepted" },
+		added:   func(j int) (bool, string) { return inputAdded(newFiles[j].Required) },
+		changed: func(i, j int, modified func(bool, string, ...interface{})) {
+			o, n := oldFiles[i], newFiles[j]
+			if o.Required != n.Required {
+				modified(n.Required, "required %v -> %v", o.Required, n.Required)
+			}
+			if o.Multiple != n.Multiple {
+				modified(o.Multiple, "multiple %v -> %v", o.Multiple, n.Multiple)
+			}
+			if o.Partial != n.Partial {
+				modified(false, "partial %v -> %v", o.Partial, n.Partial)
+			}
+			dropped, accepted := diffStrings(o.MediaTypes, n.MediaTypes)
+			// an empty list accepts every media type
+			if len(dropped) > 0 || (len(o.MediaTypes) == 0 && len(n.MediaTypes) > 0) {
+				modified(true, "media types %v -> %v", o.MediaTypes, n.MediaTypes)
+			} else if len(accepted) > 0 || (len(o.MediaTypes) > 0 && len(n.MediaTypes) == 0) {
+				modified(false, "media types %v -> %v", o.MediaTypes, n.MediaTypes)
+			}
+		},
+	}.apply(d)
+}
+
+func diffInputJson(d *SeedDiff, oldJson, newJson []InJson) {
+	sectionDiff{
+		section: "inputs.json",
+		oldLen:  len(oldJson),
+		newLen:  len(newJson),
+		oldKey:  func(i int) string { return oldJson[i].Name },
+		newKey:  func(j int) string { return newJson[j].Name },
+		removed: func(i int) (bool, string) { return true, "input no longer accepted" },
+		added:   func(j int) (bool, string) { return inputAdded(newJson[j].Required) },
+		changed: func(i, j int, modified func(bool, string, ...interface{})) {
+			o, n := oldJson[i], newJson[j]
+			if o.Type != n.Type {
+				modified(true, "type %s -> %s", o.Type, n.Type)
+			}
+			if o.Required != n.Required {
+				modified(n.Required, "required %v -> %v", o.Required, n.Required)
+			}
+		},
+	}.apply(d)
+}
+
+func diffOutputFiles(d *SeedDiff, oldFiles, newFiles []OutFile) {
+	sectionDiff{
+		section: "outputs.files",
+		oldLen:  len(oldFiles),
+		newLen:  len(newFiles),
+		oldKey:  func(i int) string { return oldFiles[i].Name },
+		newKey:  func(j int) string { return newFiles[j].Name },
+		removed: func(i int) (bool, string) { return true, "output no longer produced" },
+		added:   func(j int) (bool, string) { return false, "new output" },
+		changed: func(i, j int, modified func(bool, string, ...interface{})) {
+			o, n := oldFiles[i], newFiles[j]
+			if o.MediaType != n.MediaType {
+				modified(true, "media type %s -> %s", o.MediaType, n.MediaType)
+			}
+			if o.Multiple != n.Multiple {
+				modified(n.Multiple, "multiple %v -> %v", o.Multiple, n.Multiple)
+			}
+			if o.Required != n.Required {
+				modified(o.Required, "required %v -> %v", o.Required, n.Required)
+			}
+			if o.Pattern != n.Pattern {
+				modified(false, "pattern %s -> %s", o.Pattern, n.Pattern)
+			}
+		},
+	}.apply(d)
+}
+
+func diffOutputJson(d *SeedDiff, oldJson, newJson []OutJson) {
+	sectionDiff{
+		section: "outputs.json",
+		oldLen:  len(oldJson),
+		newLen:  len(newJson),
+		oldKey:  func(i int) string { return oldJson[i].Name },
+		newKey:  func(j int) string { return newJson[j].Name },
+		removed: func(i int) (bool, string) { return true, "output no longer produced" },
+		added:   func(j int) (bool, string) { return false, "new output" },
+		changed: func(i, j int, modified func(bool, string, ...interface{})) {
+			o, n := oldJson[i], newJson[j]
+			if o.Type != n.Type {
+				modified(true, "type %s -> %s", o.Type, n.Type)
+			}
+			if o.Required != n.Required {
+				modified(o.Required, "required %v -> %v", o.Required, n.Required)
+			}
+			if o.Key != n.Key {
+				modified(false, "key %s -> %s", o.Key, n.Key)
+			}
+		},
+	}.apply(d)
+}
+
+func diffMounts(d *SeedDiff, oldMounts, newMounts []Mount) {
+	sectionDiff{
+		section: "mounts",
+		oldLen:  len(oldMounts),
+		newLen:  len(newMounts),
+		oldKey:  func(i int) string { return oldMounts[i].Name },
+		newKey:  func(j int) string { return newMounts[j].Name },
+		removed: func(i int) (bool, string) { return false, "mount no longer used" },
+		added:   func(j int) (bool, string) { return true, "new mount must be provided" },
+		changed: func(i, j int, modified func(bool, string, ...interface{})) {
+			o, n := oldMounts[i], newMounts[j]
+			if o.Mode != n.Mode {
+				modified(n.Mode == "rw", "mode %s -> %s", o.Mode, n.Mode)
+			}
+			if o.Path != n.Path {
+				modified(false, "path %s -> %s", o.Path, n.Path)
+			}
+		},
+	}.apply(d)
+}
+
+func diffSettings(d *SeedDiff, oldSettings, newSettings []Setting) {
+	sectionDiff{
+		section: "settings",
+		oldLen:  len(oldSettings),
+		newLen:  len(newSettings),
+		oldKey:  func(i int) string { return oldSettings[i].Name },
+		newKey:  func(j int) string { return newSettings[j].Name },
+		removed: func(i int) (bool, string) { return false, "setting no longer used" },
+		added:   func(j int) (bool, string) { return true, "new setting must be provided" },
+		changed: func(i, j int, modified func(bool, string, ...interface{})) {
+			o, n := oldSettings[i], newSettings[j]
+			if o.Secret != n.Secret {
+				modified(false, "secret %v -> %v", o.Secret, n.Secret)
+			}
+		},
+	}.apply(d)
+}
+
+func diffResources(d *SeedDiff, oldScalars, newScalars []Scalar) {
+	sectionDiff{
+		section: "resources",
+		oldLen:  len(oldScalars),
+		newLen:  len(newScalars),
+		oldKey:  func(i int) string { return oldScalars[i].Name },
+		newKey:  func(j int) string { return newScalars[j].Name },
+		removed: func(i int) (bool, string) { return false, "" },
+		added:   func(j int) (bool, string) { return false, fmt.Sprintf("value %v", newScalars[j].Value) },
+		changed: func(i, j int, modified func(bool, string, ...interface{})) {
+			o, n := oldScalars[i], newScalars[j]
+			if o.Value != n.Value {
+				modified(false, "value %v -> %v", o.Value, n.Value)
+			}
+			if o.InputMultiplier != n.InputMultiplier {
+				modified(false, "inputMultiplier %v -> %v", o.InputMultiplier, n.InputMultiplier)
+			}
+		},
+	}.apply(d)
+}
+
+func diffErrors(d *SeedDiff, oldErrors, newErrors []ErrorMap) {
+	sectionDiff{
+		section: "errors",
+		oldLen:  len(oldErrors),
+		newLen:  len(newErrors),
+		oldKey:  func(i int) string { return fmt.Sprintf("%d", oldErrors[i].Code) },
+		newKey:  func(j int) string { return fmt.Sprintf("%d", newErrors[j].Code) },
+		removed: func(i int) (bool, string) { return false, oldErrors[i].Name },
+		added:   func(j int) (bool, string) { return false, newErrors[j].Name },
+		changed: func(i, j int, modified func(bool, string, ...interface{})) {
+			o, n := oldErrors[i], newErrors[j]
+			if o.Category != n.Category {
+				modified(true, "category %s -> %s", o.Category, n.Category)
+			}
+			if o.Name != n.Name || o.Title != n.Title || o.Description != n.Description {
+				modified(false, "%s -> %s", o.Name, n.Name)
+			}
+		},
+	}.apply(d)
+}
+
+//diffStrings returns the strings only in old and the strings only in updated
+func diffStrings(old, updated []string) ([]string, []string) {
+	removed, added := []string{}, []string{}
+	for _, s := range old {
+		if !containsFold(updated, s) {
+			removed = append(removed, s)
+		}
+	}
+	for _, s := range updated {
+		if !containsFold(old, s) {
+			added = append(added, s)
+		}
+	}
+	return removed, added
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
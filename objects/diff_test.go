@@ -0,0 +1,88 @@
+package objects
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffSeeds(t *testing.T) {
+	load := func() Seed {
+		seed, err := LoadSeedFromManifestFile("../testdata/complete/seed.manifest.json")
+		if err != nil {
+			t.Fatalf("Error loading complete manifest: %v", err)
+		}
+		return seed
+	}
+
+	old := load()
+
+	compatible := load()
+	compatible.Job.PackageVersion = "0.1.1"
+	compatible.Job.Interface.Inputs.Json = []InJson{{Name: "THRESHOLD", Type: "number", Required: false}}
+	compatible.Job.Interface.Outputs.Files[1].Pattern = "result*.csv"
+	compatible.Job.Resources.Scalar[0].Value = 4
+	compatible.Job.Resources.Scalar[3].InputMultiplier = 8
+	compatible.Job.Errors = append(compatible.Job.Errors, ErrorMap{Code: 3, Name: "bad-input", Category: "data"})
+
+	downgrade := load()
+	downgrade.Job.JobVersion = "0.0.9"
+	downgrade.Job.PackageVersion = "0.2.0"
+	downgrade.Job.Resources.Scalar[0].Value = 4
+
+	rewritten := load()
+	rewritten.Job.PackageVersion = "latest"
+	rewritten.Job.Resources.Scalar[0].Value = 4
+
+	breaking := load()
+	breaking.Job.Interface.Inputs.Files[0].MediaTypes = []string{"image/tiff"}
+	breaking.Job.Interface.Outputs.JSON = nil
+	breaking.Job.Interface.Mounts[0].Mode = "rw"
+	breaking.Job.Interface.Settings = append(breaking.Job.Interface.Settings, Setting{Name: "DB_PASS", Secret: true})
+
+	cases := []struct {
+		new       Seed
+		changes   []string
+		bump      VersionBump
+		satisfied bool
+	}{
+		{load(), []string{}, BumpNone, false},
+		{compatible, []string{
+			"[compatible] inputs.json THRESHOLD added: new optional input",
+			"[compatible] outputs.files output_file_csv changed: pattern outfile*.csv -> result*.csv",
+			"[compatible] resources cpus changed: value 10 -> 4",
+			"[compatible] resources disk changed: inputMultiplier 4 -> 8",
+			"[compatible] errors 3 added: bad-input",
+		}, BumpPackage, true},
+		{downgrade, []string{
+			"[compatible] resources cpus changed: value 10 -> 4",
+		}, BumpPackage, false},
+		{rewritten, []string{
+			"[compatible] resources cpus changed: value 10 -> 4",
+		}, BumpPackage, false},
+		{breaking, []string{
+			"[BREAKING] inputs.files INPUT_FILE changed: media types [image/x-hdf5-image] -> [image/tiff]",
+			"[BREAKING] outputs.json cell_count removed: output no longer produced",
+			"[BREAKING] mounts MOUNT_PATH changed: mode ro -> rw",
+			"[BREAKING] settings DB_PASS added: new setting must be provided",
+		}, BumpJob, false},
+	}
+
+	for _, c := range cases {
+		diff := DiffSeeds(&old, &c.new)
+		if len(diff.Changes) != len(c.changes) {
+			t.Errorf("DiffSeeds returned %v, expected %v", diff.Changes, c.changes)
+			continue
+		}
+		for i, change := range diff.Changes {
+			if change.String() != c.changes[i] {
+				t.Errorf("DiffSeeds returned %s, expected %s", change.String(), c.changes[i])
+			}
+		}
+		if diff.Recommended != c.bump || diff.Satisfied != c.satisfied {
+			t.Errorf("DiffSeeds recommended %v (satisfied %v), expected %v (satisfied %v)", diff.Recommended, diff.Satisfied, c.bump, c.satisfied)
+		}
+		if c.bump != BumpNone && !strings.Contains(diff.String(), "Recommendation: "+c.bump.String()+" must be bumped") {
+			t.Errorf("DiffSeeds report missing recommendation:\n%s", diff.String())
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package objects
+
+import (
+	"fmt"
+	"syscall"
+)
+
+const (
+	//ErrorCategoryJob errors are caused by the job itself
+	ErrorCategoryJob = "job"
+
+	//ErrorCategoryData errors are caused by the input data
+	ErrorCategoryData = "data"
+
+	//ErrorCategorySystem errors are synthesized for failures caused by the runtime environment
+	ErrorCategorySystem = "system"
+)
+
+//ExitStatus describes how a job container exited
+type ExitStatus struct {
+	Code int
+	//OOMKilled is reported by docker in the container's State when it was killed for exceeding its memory
+	OOMKilled bool
+	//TimedOut is set when the container was stopped because it exceeded Job.Timeout
+	TimedOut bool
+}
+
+//ExitClassification describes the error a job exit maps to and how it should be handled
+type ExitClassification struct {
+	Status ExitStatus
+	Error  ErrorMap
+	//Declared is true if Error came from the manifest rather than being synthesized
+	Declared  bool
+	Retryable bool
+}
+
+//Success checks if the job exited successfully
+func (c ExitClassification) Success() bool {
+	return c.Status.Code == 0 && !c.Status.TimedOut && !c.Status.OOMKilled
+}
+
+//Message returns a user facing description of the exit
+func (c ExitClassification) Message() string {
+	if c.Success() {
+		return "Job completed successfully"
+	}
+	title := c.Error.Title
+	if title == "" {
+		title = c.Error.Name
+	}
+	msg := fmt.Sprintf("%s (exit code %d, %s error)", title, c.Status.Code, c.Error.Category)
+	if c.Error.Description != "" {
+		msg += ": " + c.Error.Description
+	}
+	return msg
+}
+
+//ClassifyExit maps a container exit to the ErrorMap declared in the seed's Job.Errors, or to a
+// synthesized system error for timeouts, out of memory kills, signals and docker failures.
+// System errors are retryable; job and data errors fail permanently.
+func ClassifyExit(seed *Seed, status ExitStatus) ExitClassification {
+	c := ExitClassification{Status: status}
+
+	switch {
+	case status.TimedOut:
+		c.Error = systemError(status.Code, "timeout", "Job Timed Out",
+			fmt.Sprintf("The job exceeded its timeout of %d seconds", seed.Job.Timeout))
+	case status.OOMKilled:
+		c.Error = systemError(status.Code, "out-of-memory", "Out of Memory",
+			"The job was killed because it exceeded its allocated memory")
+	case status.Code == 0:
+		return c
+	default:
+		for _, e := range seed.Job.Errors {
+			if e.Code == status.Code {
+				c.Error = e
+				c.Declared = true
+				break
+			}
+		}
+	}
+
+	if !c.Declared && c.Error.Name == "" {
+		c.Error = undeclaredError(status.Code)
+	}
+
+	c.Retryable = c.Error.Category == ErrorCategorySystem
+	return c
+}
+
+//systemError returns a synthesized system error map
+func systemError(code int, name, title, description string) ErrorMap {
+	return ErrorMap{Code: code, Name: name, Title: title, Description: description, Category: ErrorCategorySystem}
+}
+
+//undeclaredError synthesizes an error map for an exit code not declared by the manifest
+func undeclaredError(code int) ErrorMap {
+	switch {
+	case code == 125:
+		return systemError(code, "docker-run-failed", "Docker Run Failed",
+			"The docker daemon was unable to run the container")
+	case code == 126:
+		return ErrorMap{Code: code, Name: "command-not-executable", Title: "Command Not Executable",
+			Description: "The job command could not be invoked", Category: ErrorCategoryJob}
+	case code == 127:
+		return ErrorMap{Code: code, Name: "command-not-found", Title: "Command Not Found",
+			Description: "The job command could not be found in the image", Category: ErrorCategoryJob}
+	case code == 137:
+		return systemError(code, "killed", "Job Killed",
+			"The job was killed (SIGKILL), possibly for exceeding its allocated memory")
+	case code > 128 && code <= 128+64:
+		sig := syscall.Signal(code - 128)
+		return systemError(code, fmt.Sprintf("signal-%d", int(sig)), "Job Terminated by Signal",
+			fmt.Sprintf("The job was terminated by signal %d (%s)", int(sig), sig.String()))
+	}
+
+	return ErrorMap{Code: code, Name: "unknown-error", Title: "Unknown Error",
+		Description: fmt.Sprintf("The job exited with undeclared exit code %d", code), Category: ErrorCategoryJob}
+}
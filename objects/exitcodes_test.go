@@ -0,0 +1,48 @@
+package objects
+
+import (
+	"testing"
+)
+
+func TestClassifyExit(t *testing.T) {
+	seed, err := LoadSeedFromManifestFile("../testdata/complete/seed.manifest.json")
+	if err != nil {
+		t.Fatalf("Error loading complete manifest: %v", err)
+	}
+
+	cases := []struct {
+		status    ExitStatus
+		name      string
+		category  string
+		declared  bool
+		retryable bool
+		message   string
+	}{
+		{ExitStatus{Code: 0}, "", "", false, false, "Job completed successfully"},
+		{ExitStatus{Code: 1}, "error-one", "data", true, false, "Error Name (exit code 1, data error): Error Description"},
+		{ExitStatus{Code: 2}, "error-two", "job", true, false, "Error Name (exit code 2, job error): Error Description"},
+		{ExitStatus{Code: 3}, "unknown-error", "job", false, false, "Unknown Error (exit code 3, job error): The job exited with undeclared exit code 3"},
+		{ExitStatus{Code: 137, OOMKilled: true}, "out-of-memory", "system", false, true, "Out of Memory (exit code 137, system error): The job was killed because it exceeded its allocated memory"},
+		{ExitStatus{Code: 137}, "killed", "system", false, true, "Job Killed (exit code 137, system error): The job was killed (SIGKILL), possibly for exceeding its allocated memory"},
+		{ExitStatus{Code: 143}, "signal-15", "system", false, true, "Job Terminated by Signal (exit code 143, system error): The job was terminated by signal 15 (terminated)"},
+		{ExitStatus{Code: 2, TimedOut: true}, "timeout", "system", false, true, "Job Timed Out (exit code 2, system error): The job exceeded its timeout of 3600 seconds"},
+		{ExitStatus{Code: 127}, "command-not-found", "job", false, false, "Command Not Found (exit code 127, job error): The job command could not be found in the image"},
+		{ExitStatus{Code: 125}, "docker-run-failed", "system", false, true, "Docker Run Failed (exit code 125, system error): The docker daemon was unable to run the container"},
+	}
+
+	for _, c := range cases {
+		result := ClassifyExit(&seed, c.status)
+		if result.Error.Name != c.name || result.Error.Category != c.category {
+			t.Errorf("ClassifyExit(%v) returned %v, expected name %v and category %v", c.status, result.Error, c.name, c.category)
+		}
+		if result.Declared != c.declared {
+			t.Errorf("ClassifyExit(%v) returned declared %v, expected %v", c.status, result.Declared, c.declared)
+		}
+		if result.Retryable != c.retryable {
+			t.Errorf("ClassifyExit(%v) returned retryable %v, expected %v", c.status, result.Retryable, c.retryable)
+		}
+		if result.Message() != c.message {
+			t.Errorf("ClassifyExit(%v) returned message %q, expected %q", c.status, result.Message(), c.message)
+		}
+	}
+}
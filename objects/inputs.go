@@ -0,0 +1,135 @@
+package objects
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ngageoint/seed-common/util"
+)
+
+//InputViolation describes a json input that does not satisfy the manifest
+type InputViolation struct {
+	Name    string
+	Message string
+}
+
+//String formats the violation as name: message
+func (v InputViolation) String() string {
+	return fmt.Sprintf("%s: %s", v.Name, v.Message)
+}
+
+//ValidateJsonInputs checks the given json input values against the seed's Interface.Inputs.Json.
+// String values (i.e. from ArrayFlags KEY=VAL pairs) are coerced into the declared type. The
+// coerced values are returned keyed by the declared input name, along with any missing required
+// inputs, inputs supplied under more than one name, unknown names and type mismatches.
+func ValidateJsonInputs(seed *Seed, values map[string]interface{}) (map[string]interface{}, []InputViolation) {
+	coerced := make(map[string]interface{})
+	violations := []InputViolation{}
+	declared := seed.Job.Interface.Inputs.Json
+
+	// match supplied names to declared inputs, allowing the normalized form of the name
+	matched := make(map[string]bool)
+	for _, in := range declared {
+		names := []string{}
+		for supplied := range values {
+			if supplied == in.Name || util.GetNormalizedVariable(supplied) == util.GetNormalizedVariable(in.Name) {
+				names = append(names, supplied)
+				matched[supplied] = true
+			}
+		}
+
+		if len(names) == 0 {
+			if in.Required {
+				violations = append(violations, InputViolation{in.Name, "required input not provided"})
+			}
+			continue
+		}
+		if len(names) > 1 {
+			sort.Strings(names)
+			violations = append(violations, InputViolation{in.Name, "provided more than once as " + strings.Join(names, ", ")})
+			continue
+		}
+		name := names[0]
+
+		value, err := CoerceJsonValue(values[name], in.Type)
+		if err != nil {
+			violations = append(violations, InputViolation{in.Name, err.Error()})
+			continue
+		}
+		coerced[in.Name] = value
+	}
+
+	unknown := []string{}
+	for supplied := range values {
+		if !matched[supplied] {
+			unknown = append(unknown, supplied)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		violations = append(violations, InputViolation{name, "not declared as a json input"})
+	}
+
+	return coerced, violations
+}
+
+//ValidateJsonInputFlags parses KEY=VAL flags and validates them with ValidateJsonInputs
+func ValidateJsonInputFlags(seed *Seed, flags ArrayFlags) (map[string]interface{}, []InputViolation, error) {
+	pairs, err := flags.KeyValues()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values := make(map[string]interface{})
+	for key, value := range pairs {
+		values[key] = value
+	}
+
+	coerced, violations := ValidateJsonInputs(seed, values)
+	return coerced, violations, nil
+}
+
+//CoerceJsonValue converts the value into the given seed json type (string, integer, number,
+// boolean, object or array). Strings are parsed into the declared type when it is not string.
+func CoerceJsonValue(value interface{}, jsonType string) (interface{}, error) {
+	var raw []byte
+	if s, ok := value.(string); ok {
+		if jsonType == "string" {
+			return s, nil
+		}
+		if jsonType == "boolean" {
+			if b, err := strconv.ParseBool(s); err == nil {
+				return b, nil
+			}
+		}
+		raw = []byte(s)
+	} else {
+		var err error
+		if raw, err = json.Marshal(value); err != nil {
+			return nil, fmt.Errorf("value %v is not valid json. %s", value, err.Error())
+		}
+	}
+
+	var decoded interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("value %v is not of type %s", value, jsonType)
+	}
+	// the value must be a single json value, i.e. "3 4" is not an integer
+	var trailing interface{}
+	if err := decoder.Decode(&trailing); err != io.EOF {
+		return nil, fmt.Errorf("value %v is not of type %s", value, jsonType)
+	}
+
+	converted, ok := convertJsonType(decoded, jsonType)
+	if !ok {
+		return nil, fmt.Errorf("value %v is not of type %s", value, jsonType)
+	}
+	return converted, nil
+}
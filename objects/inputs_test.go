@@ -0,0 +1,105 @@
+package objects
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestValidateJsonInputs(t *testing.T) {
+	seed := Seed{Job: Job{Interface: Interface{Inputs: Inputs{Json: []InJson{
+		{Name: "count", Type: "integer", Required: true},
+		{Name: "ratio", Type: "number", Required: false},
+		{Name: "verbose", Type: "boolean", Required: false},
+		{Name: "label", Type: "string", Required: false},
+		{Name: "options", Type: "object", Required: false},
+		{Name: "bands", Type: "array", Required: false},
+	}}}}}
+
+	cases := []struct {
+		values     map[string]interface{}
+		coerced    string
+		violations string
+	}{
+		{map[string]interface{}{"count": "3", "ratio": "0.5", "verbose": "true", "label": "12",
+			"options": `{"a": 1}`, "bands": "[1, 2]"},
+			"map[bands:[1 2] count:3 label:12 options:map[a:1] ratio:0.5 verbose:true]", "[]"},
+		{map[string]interface{}{"COUNT": 3, "ratio": 2, "bands": []string{"r", "g"}},
+			"map[bands:[r g] count:3 ratio:2]", "[]"},
+		{map[string]interface{}{"count": "3.5", "verbose": "maybe", "label": 7, "extra": "x"},
+			"map[]",
+			"[count: value 3.5 is not of type integer verbose: value maybe is not of type boolean label: value 7 is not of type string extra: not declared as a json input]"},
+		{map[string]interface{}{}, "map[]", "[count: required input not provided]"},
+		{map[string]interface{}{"count": 1, "COUNT": 2, "Count": 3}, "map[]",
+			"[count: provided more than once as COUNT, Count, count]"},
+	}
+
+	for _, c := range cases {
+		coerced, violations := ValidateJsonInputs(&seed, c.values)
+		if fmt.Sprintf("%v", coerced) != c.coerced {
+			t.Errorf("ValidateJsonInputs(%v) returned %v, expected %v", c.values, coerced, c.coerced)
+		}
+		if fmt.Sprintf("%v", violations) != c.violations {
+			t.Errorf("ValidateJsonInputs(%v) returned violations %v, expected %v", c.values, violations, c.violations)
+		}
+	}
+}
+
+func TestValidateJsonInputFlags(t *testing.T) {
+	seed := Seed{Job: Job{Interface: Interface{Inputs: Inputs{Json: []InJson{
+		{Name: "expr", Type: "string", Required: true},
+	}}}}}
+
+	cases := []struct {
+		flags   ArrayFlags
+		coerced string
+		errStr  string
+	}{
+		{ArrayFlags{"expr=a=b"}, "map[expr:a=b]", ""},
+		{ArrayFlags{"expr"}, "map[]", "Expected KEY=VAL"},
+	}
+
+	for _, c := range cases {
+		coerced, _, err := ValidateJsonInputFlags(&seed, c.flags)
+		if fmt.Sprintf("%v", coerced) != c.coerced {
+			t.Errorf("ValidateJsonInputFlags(%v) returned %v, expected %v", c.flags, coerced, c.coerced)
+		}
+		if err == nil && c.errStr != "" {
+			t.Errorf("ValidateJsonInputFlags(%v) did not return an error when one was expected: %v", c.flags, c.errStr)
+		}
+		if err != nil && (c.errStr == "" || !strings.Contains(err.Error(), c.errStr)) {
+			t.Errorf("ValidateJsonInputFlags(%v) returned an error: %v\n expected %v", c.flags, err, c.errStr)
+		}
+	}
+}
+
+func TestCoerceJsonValue(t *testing.T) {
+	cases := []struct {
+		value    interface{}
+		jsonType string
+		coerced  string
+		errStr   string
+	}{
+		{"3", "integer", "3", ""},
+		{" 3 ", "integer", "3", ""},
+		{"3 4", "integer", "<nil>", "value 3 4 is not of type integer"},
+		{"1.5}", "number", "<nil>", "value 1.5} is not of type number"},
+		{"[1,2] x", "array", "<nil>", "value [1,2] x is not of type array"},
+		{`{"a": 1}{"b": 2}`, "object", "<nil>", "is not of type object"},
+		{"true false", "boolean", "<nil>", "value true false is not of type boolean"},
+		{"3 4", "string", "3 4", ""},
+	}
+
+	for _, c := range cases {
+		coerced, err := CoerceJsonValue(c.value, c.jsonType)
+		if fmt.Sprintf("%v", coerced) != c.coerced {
+			t.Errorf("CoerceJsonValue(%q, %v) returned %v, expected %v", c.value, c.jsonType, coerced, c.coerced)
+		}
+		if err == nil && c.errStr != "" {
+			t.Errorf("CoerceJsonValue(%q, %v) did not return an error when one was expected: %v", c.value, c.jsonType, c.errStr)
+		}
+		if err != nil && (c.errStr == "" || !strings.Contains(err.Error(), c.errStr)) {
+			t.Errorf("CoerceJsonValue(%q, %v) returned an error: %v\n expected %v", c.value, c.jsonType, err, c.errStr)
+		}
+	}
+}
@@ -0,0 +1,385 @@
+package objects
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//CurrentSeedVersion defines the seed version that legacy manifests are migrated to
+const CurrentSeedVersion = "1.0.0"
+
+//legacyOutputDirRef matches the $JOB_OUTPUT_DIR and ${JOB_OUTPUT_DIR} references in a legacy command
+var legacyOutputDirRef = regexp.MustCompile(`\$\{JOB_OUTPUT_DIR\}|\$JOB_OUTPUT_DIR\b`)
+
+//MigrationIssue describes a legacy manifest field that could not be mapped exactly
+type MigrationIssue struct {
+	Path   string
+	Reason string
+}
+
+//String formats the migration issue as path: reason
+func (i MigrationIssue) String() string {
+	return fmt.Sprintf("%s: %s", i.Path, i.Reason)
+}
+
+//MigrationReport describes the result of converting a legacy manifest to the current seed version
+type MigrationReport struct {
+	FromVersion string
+	ToVersion   string
+	//Unmapped lists legacy fields that have no equivalent and were dropped
+	Unmapped []MigrationIssue
+	//Adjusted lists fields whose values had to be changed or synthesized
+	Adjusted []MigrationIssue
+}
+
+//IsLegacyManifest checks if the given manifest uses the pre-1.0.0 (manifestVersion) layout
+func IsLegacyManifest(manifest []byte) bool {
+	var probe struct {
+		SeedVersion     *string `json:"seedVersion"`
+		ManifestVersion *string `json:"manifestVersion"`
+	}
+	if err := json.Unmarshal(manifest, &probe); err != nil {
+		return false
+	}
+	return probe.SeedVersion == nil && probe.ManifestVersion != nil
+}
+
+//DecodeManifest returns the seed parsed from the given manifest, detecting the manifest version.
+// Legacy manifests are migrated to the current seed version and a report of the migration is
+// returned; the report is nil for current manifests.
+func DecodeManifest(manifest []byte) (Seed, *MigrationReport, error) {
+	if IsLegacyManifest(manifest) {
+		seed, report, err := MigrateLegacyManifest(manifest)
+		return seed, &report, err
+	}
+
+	seed := Seed{}
+	if err := json.Unmarshal(manifest, &seed); err != nil {
+		return Seed{}, nil, &ManifestParseError{Source: "from manifest", Err: err}
+	}
+	return seed, nil, nil
+}
+
+//MigrateLegacyManifest converts a legacy (manifestVersion 0.0.x) manifest into the current seed
+// format. Fields that cannot be mapped are listed in the returned report.
+func MigrateLegacyManifest(manifest []byte) (Seed, MigrationReport, error) {
+	report := MigrationReport{ToVersion: CurrentSeedVersion}
+
+	legacy := map[string]interface{}{}
+	if err := json.Unmarshal(manifest, &legacy); err != nil {
+		return Seed{}, report, &ManifestParseError{Source: "from legacy manifest", Err: err}
+	}
+
+	d := &legacyDecoder{report: &report}
+	report.FromVersion = d.str(legacy, "manifestVersion", "manifestVersion")
+
+	seed := Seed{SeedVersion: CurrentSeedVersion}
+	if job, ok := d.object(legacy, "job", "job"); ok {
+		seed.Job = d.job(job, "job")
+	}
+	d.leftovers(legacy, "")
+
+	sort.Slice(report.Unmapped, func(i, j int) bool { return report.Unmapped[i].Path < report.Unmapped[j].Path })
+
+	return seed, report, nil
+}
+
+//legacyDecoder walks a generic legacy manifest, consuming the keys it understands
+type legacyDecoder struct {
+	report *MigrationReport
+}
+
+func (d *legacyDecoder) unmapped(path, reason string) {
+	d.report.Unmapped = append(d.report.Unmapped, MigrationIssue{Path: path, Reason: reason})
+}
+
+func (d *legacyDecoder) adjusted(path, reason string) {
+	d.report.Adjusted = append(d.report.Adjusted, MigrationIssue{Path: path, Reason: reason})
+}
+
+func (d *legacyDecoder) take(obj map[string]interface{}, key string) (interface{}, bool) {
+	v, ok := obj[key]
+	delete(obj, key)
+	return v, ok && v != nil
+}
+
+func (d *legacyDecoder) str(obj map[string]interface{}, key, path string) string {
+	v, ok := d.take(obj, key)
+	if !ok {
+		return ""
+	}
+	switch t := v.(type) {
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	}
+	d.unmapped(path, fmt.Sprintf("expected a string, found %v", v))
+	return ""
+}
+
+func (d *legacyDecoder) boolean(obj map[string]interface{}, key, path string, def bool) bool {
+	v, ok := d.take(obj, key)
+	if !ok {
+		return def
+	}
+	if b, ok := v.(bool); ok {
+		return b
+	}
+	d.unmapped(path, fmt.Sprintf("expected a boolean, found %v", v))
+	return def
+}
+
+func (d *legacyDecoder) number(obj map[string]interface{}, key, path string) (float64, bool) {
+	v, ok := d.take(obj, key)
+	if !ok {
+		return 0, false
+	}
+	switch t := v.(type) {
+	case float64:
+		return t, true
+	case string:
+		if f, err := strconv.ParseFloat(t, 64); err == nil {
+			return f, true
+		}
+	}
+	d.unmapped(path, fmt.Sprintf("expected a number, found %v", v))
+	return 0, false
+}
+
+func (d *legacyDecoder) strings(obj map[string]interface{}, key, path string) []string {
+	v, ok := d.take(obj, key)
+	if !ok {
+		return nil
+	}
+	list, ok := v.([]interface{})
+	if !ok {
+		d.unmapped(path, fmt.Sprintf("expected a list of strings, found %v", v))
+		return nil
+	}
+	result := []string{}
+	for i, item := range list {
+		if s, ok := item.(string); ok {
+			result = append(result, s)
+		} else {
+			d.unmapped(fmt.Sprintf("%s[%d]", path, i), fmt.Sprintf("expected a string, found %v", item))
+		}
+	}
+	return result
+}
+
+func (d *legacyDecoder) object(obj map[string]interface{}, key, path string) (map[string]interface{}, bool) {
+	v, ok := d.take(obj, key)
+	if !ok {
+		return nil, false
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		d.unmapped(path, fmt.Sprintf("expected an object, found %v", v))
+	}
+	return m, ok
+}
+
+//objects returns the objects in the list under key along with the path of each
+func (d *legacyDecoder) objects(obj map[string]interface{}, key, path string) ([]map[string]interface{}, []string) {
+	v, ok := d.take(obj, key)
+	if !ok {
+		return nil, nil
+	}
+	list, ok := v.([]interface{})
+	if !ok {
+		d.unmapped(path, fmt.Sprintf("expected a list, found %v", v))
+		return nil, nil
+	}
+	result := []map[string]interface{}{}
+	paths := []string{}
+	for i, item := range list {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		if m, ok := item.(map[string]interface{}); ok {
+			result = append(result, m)
+			paths = append(paths, itemPath)
+		} else {
+			d.unmapped(itemPath, fmt.Sprintf("expected an object, found %v", item))
+		}
+	}
+	return result, paths
+}
+
+//leftovers reports any keys that were not consumed while decoding obj
+func (d *legacyDecoder) leftovers(obj map[string]interface{}, path string) {
+	for key := range obj {
+		keyPath := key
+		if path != "" {
+			keyPath = path + "." + key
+		}
+		d.unmapped(keyPath, "no equivalent field in seed "+CurrentSeedVersion)
+	}
+}
+
+func (d *legacyDecoder) job(obj map[string]interface{}, path string) Job {
+	job := Job{
+		Name:           d.str(obj, "name", path+".name"),
+		JobVersion:     d.str(obj, "jobVersion", path+".jobVersion"),
+		PackageVersion: d.str(obj, "packageVersion", path+".packageVersion"),
+		Title:          d.str(obj, "title", path+".title"),
+		Description:    d.str(obj, "description", path+".description"),
+		Tags:           d.strings(obj, "tag", path+".tag"),
+		Maintainer: Maintainer{
+			Name:  d.str(obj, "authorName", path+".authorName"),
+			Email: d.str(obj, "authorEmail", path+".authorEmail"),
+			Url:   d.str(obj, "authorUrl", path+".authorUrl"),
+		},
+	}
+	if timeout, ok := d.number(obj, "timeout", path+".timeout"); ok {
+		job.Timeout = int(timeout)
+	}
+
+	// resources were declared directly on the job
+	resources := []struct{ legacy, scalar string }{
+		{"cpus", "cpus"}, {"mem", "mem"}, {"sharedMem", "sharedMem"}, {"storage", "disk"},
+	}
+	for _, r := range resources {
+		if value, ok := d.number(obj, r.legacy, path+"."+r.legacy); ok {
+			job.Resources.Scalar = append(job.Resources.Scalar, Scalar{Name: r.scalar, Value: value})
+		}
+	}
+
+	if iface, ok := d.object(obj, "interface", path+".interface"); ok {
+		job.Interface = d.iface(iface, path+".interface")
+	}
+
+	errs, paths := d.objects(obj, "errorMapping", path+".errorMapping")
+	for i, e := range errs {
+		job.Errors = append(job.Errors, d.errorMap(e, paths[i]))
+	}
+
+	d.leftovers(obj, path)
+	return job
+}
+
+func (d *legacyDecoder) iface(obj map[string]interface{}, path string) Interface {
+	iface := Interface{}
+
+	iface.Command = d.str(obj, "args", path+".args")
+	if legacyOutputDirRef.MatchString(iface.Command) {
+		iface.Command = legacyOutputDirRef.ReplaceAllStringFunc(iface.Command, func(ref string) string {
+			return strings.Replace(ref, "JOB_OUTPUT_DIR", OutputDirVariable, 1)
+		})
+		d.adjusted(path+".args", "JOB_OUTPUT_DIR renamed to OUTPUT_DIR")
+	}
+
+	if inputs, ok := d.object(obj, "inputData", path+".inputData"); ok {
+		inPath := path + ".inputData"
+		files, paths := d.objects(inputs, "files", inPath+".files")
+		for i, f := range files {
+			iface.Inputs.Files = append(iface.Inputs.Files, InFile{
+				Name:       d.str(f, "name", paths[i]+".name"),
+				MediaTypes: d.strings(f, "mediaType", paths[i]+".mediaType"),
+				Multiple:   d.boolean(f, "multiple", paths[i]+".multiple", false),
+				Partial:    d.boolean(f, "partial", paths[i]+".partial", false),
+				Required:   d.boolean(f, "required", paths[i]+".required", true),
+			})
+			d.leftovers(f, paths[i])
+		}
+		jsons, paths := d.objects(inputs, "json", inPath+".json")
+		for i, j := range jsons {
+			iface.Inputs.Json = append(iface.Inputs.Json, InJson{
+				Name:     d.str(j, "name", paths[i]+".name"),
+				Type:     d.str(j, "type", paths[i]+".type"),
+				Required: d.boolean(j, "required", paths[i]+".required", true),
+			})
+			d.leftovers(j, paths[i])
+		}
+		d.leftovers(inputs, inPath)
+	}
+
+	if outputs, ok := d.object(obj, "outputData", path+".outputData"); ok {
+		outPath := path + ".outputData"
+		files, paths := d.objects(outputs, "files", outPath+".files")
+		for i, f := range files {
+			outFile := OutFile{
+				Name:      d.str(f, "name", paths[i]+".name"),
+				MediaType: d.str(f, "mediaType", paths[i]+".mediaType"),
+				Pattern:   d.str(f, "pattern", paths[i]+".pattern"),
+				Multiple:  d.boolean(f, "multiple", paths[i]+".multiple", false),
+				Required:  d.boolean(f, "required", paths[i]+".required", true),
+			}
+			if count := d.str(f, "count", paths[i]+".count"); count != "" && count != "1" {
+				outFile.Multiple = true
+				d.adjusted(paths[i]+".count", fmt.Sprintf("count %s converted to multiple", count))
+			}
+			iface.Outputs.Files = append(iface.Outputs.Files, outFile)
+			d.leftovers(f, paths[i])
+		}
+		jsons, paths := d.objects(outputs, "json", outPath+".json")
+		for i, j := range jsons {
+			iface.Outputs.JSON = append(iface.Outputs.JSON, OutJson{
+				Name:     d.str(j, "name", paths[i]+".name"),
+				Key:      d.str(j, "key", paths[i]+".key"),
+				Type:     d.str(j, "type", paths[i]+".type"),
+				Required: d.boolean(j, "required", paths[i]+".required", true),
+			})
+			d.leftovers(j, paths[i])
+		}
+		d.leftovers(outputs, outPath)
+	}
+
+	mounts, paths := d.objects(obj, "mounts", path+".mounts")
+	for i, m := range mounts {
+		mount := Mount{
+			Name: d.str(m, "name", paths[i]+".name"),
+			Path: d.str(m, "path", paths[i]+".path"),
+			Mode: d.str(m, "mode", paths[i]+".mode"),
+		}
+		if mount.Mode == "" {
+			mount.Mode = "ro"
+		}
+		iface.Mounts = append(iface.Mounts, mount)
+		d.leftovers(m, paths[i])
+	}
+
+	settings, paths := d.objects(obj, "settings", path+".settings")
+	for i, s := range settings {
+		iface.Settings = append(iface.Settings, Setting{
+			Name:   d.str(s, "name", paths[i]+".name"),
+			Secret: d.boolean(s, "secret", paths[i]+".secret", false),
+		})
+		d.leftovers(s, paths[i])
+	}
+
+	d.leftovers(obj, path)
+	return iface
+}
+
+func (d *legacyDecoder) errorMap(obj map[string]interface{}, path string) ErrorMap {
+	errorMap := ErrorMap{
+		Name:        d.str(obj, "name", path+".name"),
+		Title:       d.str(obj, "title", path+".title"),
+		Description: d.str(obj, "description", path+".description"),
+		Category:    d.str(obj, "category", path+".category"),
+	}
+	if code, ok := d.number(obj, "code", path+".code"); ok {
+		errorMap.Code = int(code)
+	}
+
+	if errorMap.Name == "" {
+		errorMap.Name = fmt.Sprintf("error-%d", errorMap.Code)
+		d.adjusted(path+".name", "name synthesized as "+errorMap.Name)
+	}
+
+	switch errorMap.Category {
+	case ErrorCategoryJob, ErrorCategoryData:
+	case "":
+		errorMap.Category = ErrorCategoryJob
+	default:
+		d.adjusted(path+".category", fmt.Sprintf("unsupported category %s converted to job", errorMap.Category))
+		errorMap.Category = ErrorCategoryJob
+	}
+
+	d.leftovers(obj, path)
+	return errorMap
+}
@@ -0,0 +1,106 @@
+package objects
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ngageoint/seed-common/util"
+)
+
+func TestIsLegacyManifest(t *testing.T) {
+	cases := []struct {
+		manifest string
+		legacy   bool
+	}{
+		{`{"manifestVersion": "0.0.3", "job": {}}`, true},
+		{`{"seedVersion": "1.0.0", "job": {}}`, false},
+		{`{"seedVersion": "1.0.0", "manifestVersion": "0.0.3"}`, false},
+		{`not json`, false},
+	}
+
+	for _, c := range cases {
+		legacy := IsLegacyManifest([]byte(c.manifest))
+		if legacy != c.legacy {
+			t.Errorf("IsLegacyManifest(%q) returned %v, expected %v", c.manifest, legacy, c.legacy)
+		}
+	}
+}
+
+func TestMigrateLegacyCommand(t *testing.T) {
+	cases := []struct {
+		args    string
+		command string
+	}{
+		{"${INPUT} ${JOB_OUTPUT_DIR}", "${INPUT} ${OUTPUT_DIR}"},
+		{"-o $JOB_OUTPUT_DIR/out.txt", "-o $OUTPUT_DIR/out.txt"},
+		{"${MY_JOB_OUTPUT_DIR} $JOB_OUTPUT_DIRS $MY_JOB_OUTPUT_DIR", "${MY_JOB_OUTPUT_DIR} $JOB_OUTPUT_DIRS $MY_JOB_OUTPUT_DIR"},
+		{"--dir=${JOB_OUTPUT_DIR} --keep JOB_OUTPUT_DIR", "--dir=${OUTPUT_DIR} --keep JOB_OUTPUT_DIR"},
+	}
+
+	for _, c := range cases {
+		manifest := fmt.Sprintf(`{"manifestVersion": "0.0.3", "job": {"name": "my-job", "interface": {"args": %q}}}`, c.args)
+		seed, _, err := DecodeManifest([]byte(manifest))
+		if err != nil {
+			t.Errorf("DecodeManifest(%q) returned an error: %v", manifest, err)
+			continue
+		}
+		if seed.Job.Interface.Command != c.command {
+			t.Errorf("MigrateLegacyManifest migrated the args %q to %q, expected %q", c.args, seed.Job.Interface.Command, c.command)
+		}
+	}
+}
+
+func TestMigrateLegacyManifest(t *testing.T) {
+	lines, err := util.ReadLinesFromFile("../testdata/complete/Dockerfile")
+	if err != nil {
+		t.Fatalf("Error reading legacy Dockerfile: %v", err)
+	}
+	label := ""
+	for _, line := range lines {
+		if strings.HasPrefix(line, "LABEL com.ngageoint.seed.manifest=") {
+			label = util.UnescapeManifestLabel(strings.TrimPrefix(line, "LABEL com.ngageoint.seed.manifest="))
+		}
+	}
+
+	seed, report, err := DecodeManifest([]byte(label))
+	if err != nil {
+		t.Fatalf("DecodeManifest returned an error: %v", err)
+	}
+	if report == nil {
+		t.Fatalf("DecodeManifest did not return a migration report for a legacy manifest")
+	}
+
+	cases := []struct {
+		field    string
+		value    interface{}
+		expected string
+	}{
+		{"seedVersion", seed.SeedVersion, "1.0.0"},
+		{"fromVersion", report.FromVersion, "0.0.3"},
+		{"name", seed.Job.Name, "my-job"},
+		{"tags", seed.Job.Tags, "[hdf5 tiff csv image processing]"},
+		{"maintainer", seed.Job.Maintainer, "{John Doe  jdoe@example.com http://www.example.com }"},
+		{"timeout", seed.Job.Timeout, "3600"},
+		{"command", seed.Job.Interface.Command, "${INPUT_FILE} ${OUTPUT_DIR}"},
+		{"inputs", seed.Job.Interface.Inputs.Files, "[{INPUT_FILE [image/x-hdf5-image] false false true}]"},
+		{"outputs", seed.Job.Interface.Outputs.Files, "[{output_file_tiffs image/tiff true outfile*.tif true} {output_file_csv text/csv false outfile*.csv true}]"},
+		{"json", seed.Job.Interface.Outputs.JSON, "[{cell_count cellCount integer true}]"},
+		{"mounts", seed.Job.Interface.Mounts, "[{MOUNT1 /the/container/path ro}]"},
+		{"settings", seed.Job.Interface.Settings, "[{SETTING1 false}]"},
+		{"resources", seed.Job.Resources.Scalar, "[{cpus 10 0} {mem 10240 0} {sharedMem 0 0} {disk 0 0}]"},
+		{"errors", seed.Job.Errors, "[{1 error-1 Error Name Error Description job} {2 error-2 Error Name Error Description data} {3 error-3 Error Name Error Description job}]"},
+		{"unmapped", report.Unmapped, "[job.interface.envVars: no equivalent field in seed 1.0.0]"},
+	}
+
+	for _, c := range cases {
+		result := fmt.Sprintf("%v", c.value)
+		if result != c.expected {
+			t.Errorf("MigrateLegacyManifest returned %s for %s, expected %s", result, c.field, c.expected)
+		}
+	}
+
+	if len(report.Adjusted) != 6 {
+		t.Errorf("MigrateLegacyManifest returned adjustments %v, expected 6", report.Adjusted)
+	}
+}
@@ -0,0 +1,172 @@
+package objects
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ngageoint/seed-common/constants"
+	"github.com/ngageoint/seed-common/util"
+)
+
+//Severity defines how serious a lint finding is
+type Severity string
+
+const (
+	//SeverityError findings violate the seed spec
+	SeverityError Severity = "error"
+
+	//SeverityWarning findings are legal but likely to cause problems
+	SeverityWarning Severity = "warning"
+)
+
+//StandardScalars defines the resource names every seed runtime allocates
+var StandardScalars = []string{"cpus", "mem", "disk", "sharedMem"}
+
+//ErrorCategories defines the valid ErrorMap categories
+var ErrorCategories = []string{ErrorCategoryJob, ErrorCategoryData}
+
+//Finding describes a single semantic problem found in a seed manifest
+type Finding struct {
+	Severity Severity
+	Path     string
+	Message  string
+}
+
+//String formats the finding as severity path: message
+func (f Finding) String() string {
+	return fmt.Sprintf("%s %s: %s", f.Severity, f.Path, f.Message)
+}
+
+//AllocatedVariables returns the ALLOCATED_* environment variable names reserved for the
+// standard scalars and any custom scalars declared by the seed
+func AllocatedVariables(seed *Seed) []string {
+	allocated := []string{}
+	names := append([]string{}, StandardScalars...)
+	for _, scalar := range seed.Job.Resources.Scalar {
+		names = append(names, scalar.Name)
+	}
+	for _, name := range names {
+		variable := "ALLOCATED_" + util.GetNormalizedVariable(name)
+		if !util.ContainsString(allocated, variable) {
+			allocated = append(allocated, variable)
+		}
+	}
+	return allocated
+}
+
+//Lint checks a seed for semantic problems the schema cannot catch: invalid or unsupported versions,
+// variable names that collide after normalization, names that are not normalized, use of reserved
+// names, duplicate error codes and unknown error categories.
+func Lint(seed *Seed) []Finding {
+	findings := lintVersions(seed)
+	allocated := AllocatedVariables(seed)
+	inUse := make(map[string][]string)
+
+	checkName := func(name, path string) {
+		if name == "" {
+			findings = append(findings, Finding{SeverityError, path, "name is empty"})
+			return
+		}
+		normName := util.GetNormalizedVariable(name)
+		if util.IsReserved(normName, allocated) || strings.HasPrefix(normName, "ALLOCATED_") {
+			findings = append(findings, Finding{SeverityError, path,
+				fmt.Sprintf("%s is a reserved variable name", name)})
+		}
+		if util.IsInUse(name, path, inUse) {
+			findings = append(findings, Finding{SeverityError, path,
+				fmt.Sprintf("%s collides with %s after normalization to %s", name, inUse[normName][0], normName)})
+		}
+		notNormalized := make(map[string]string)
+		if !util.IsNormalized(name, path, notNormalized) {
+			findings = append(findings, Finding{SeverityWarning, path,
+				fmt.Sprintf("%s is not normalized; it will be available as %s", name, normName)})
+		}
+	}
+
+	iface := seed.Job.Interface
+	for i, f := range iface.Inputs.Files {
+		checkName(f.Name, fmt.Sprintf("job.interface.inputs.files[%d].name", i))
+	}
+	for i, j := range iface.Inputs.Json {
+		checkName(j.Name, fmt.Sprintf("job.interface.inputs.json[%d].name", i))
+	}
+	for i, f := range iface.Outputs.Files {
+		checkName(f.Name, fmt.Sprintf("job.interface.outputs.files[%d].name", i))
+	}
+	for i, j := range iface.Outputs.JSON {
+		checkName(j.Name, fmt.Sprintf("job.interface.outputs.json[%d].name", i))
+	}
+	for i, m := range iface.Mounts {
+		checkName(m.Name, fmt.Sprintf("job.interface.mounts[%d].name", i))
+	}
+	for i, s := range iface.Settings {
+		checkName(s.Name, fmt.Sprintf("job.interface.settings[%d].name", i))
+	}
+
+	codes := make(map[int]string)
+	for i, e := range seed.Job.Errors {
+		path := fmt.Sprintf("job.errors[%d]", i)
+		if first, exists := codes[e.Code]; exists {
+			findings = append(findings, Finding{SeverityError, path + ".code",
+				fmt.Sprintf("error code %d is already declared at %s", e.Code, first)})
+		} else {
+			codes[e.Code] = path + ".code"
+		}
+		if !util.ContainsString(ErrorCategories, e.Category) {
+			findings = append(findings, Finding{SeverityError, path + ".category",
+				fmt.Sprintf("unknown error category %q; expected one of %v", e.Category, ErrorCategories)})
+		}
+	}
+
+	return findings
+}
+
+//validateSeed returns the violations of the manifest schema and the lint errors of the seed as
+// path: message problems
+func validateSeed(seed *Seed) []string {
+	manifest, err := json.Marshal(seed)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	problems := []string{}
+	schemaErrors, err := util.Validate(manifest, constants.SchemaManifest)
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+	for _, e := range schemaErrors {
+		problems = append(problems, e.String())
+	}
+	for _, f := range Lint(seed) {
+		if f.Severity == SeverityError {
+			problems = append(problems, fmt.Sprintf("%s: %s", f.Path, f.Message))
+		}
+	}
+	return problems
+}
+
+//lintVersions checks that the seed's versions are semantic versions and the seedVersion is supported
+func lintVersions(seed *Seed) []Finding {
+	findings := []Finding{}
+
+	versions := []struct{ path, version string }{
+		{"seedVersion", seed.SeedVersion},
+		{"job.jobVersion", seed.Job.JobVersion},
+		{"job.packageVersion", seed.Job.PackageVersion},
+	}
+	for _, v := range versions {
+		if !util.IsValidVersion(v.version) {
+			findings = append(findings, Finding{SeverityError, v.path,
+				fmt.Sprintf("%q is not a valid semantic version", v.version)})
+		}
+	}
+
+	supported, err := util.ParseConstraint(constants.SupportedSeedVersions)
+	if err == nil && util.IsValidVersion(seed.SeedVersion) && !supported.CheckString(seed.SeedVersion) {
+		findings = append(findings, Finding{SeverityError, "seedVersion",
+			fmt.Sprintf("seed version %s is not supported; expected %s", seed.SeedVersion, supported)})
+	}
+
+	return findings
+}
@@ -0,0 +1,80 @@
+package objects
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	complete, err := LoadSeedFromManifestFile("../testdata/complete/seed.manifest.json")
+	if err != nil {
+		t.Fatalf("Error loading complete manifest: %v", err)
+	}
+
+	unsupported := complete
+	unsupported.SeedVersion = "2.0.0"
+	unsupported.Job.JobVersion = "1.0"
+	unsupported.Job.Interface = Interface{}
+
+	collisions := Seed{Job: Job{
+		Interface: Interface{
+			Inputs: Inputs{
+				Files: []InFile{{Name: "INPUT_FILE"}, {Name: "input-file"}},
+				Json:  []InJson{{Name: "OUTPUT_DIR"}},
+			},
+			Mounts:   []Mount{{Name: "ALLOCATED_GPUS"}},
+			Settings: []Setting{{Name: "ALLOCATED_CPUS"}, {Name: ""}},
+		},
+		Errors: []ErrorMap{{Code: 1, Category: "job"}, {Code: 1, Category: "system"}},
+	}}
+
+	cases := []struct {
+		seed     Seed
+		expected []string
+	}{
+		{complete, []string{
+			"warning job.interface.outputs.files[0].name: output_file_tiffs is not normalized; it will be available as OUTPUT_FILE_TIFFS",
+			"warning job.interface.outputs.files[1].name: output_file_csv is not normalized; it will be available as OUTPUT_FILE_CSV",
+			"warning job.interface.outputs.json[0].name: cell_count is not normalized; it will be available as CELL_COUNT",
+		}},
+		{unsupported, []string{
+			"error job.jobVersion: \"1.0\" is not a valid semantic version",
+			"error seedVersion: seed version 2.0.0 is not supported; expected ^1.0.0",
+		}},
+		{collisions, []string{
+			"error seedVersion: \"\" is not a valid semantic version",
+			"error job.jobVersion: \"\" is not a valid semantic version",
+			"error job.packageVersion: \"\" is not a valid semantic version",
+			"error job.interface.inputs.files[1].name: input-file collides with job.interface.inputs.files[0].name after normalization to INPUT_FILE",
+			"warning job.interface.inputs.files[1].name: input-file is not normalized; it will be available as INPUT_FILE",
+			"error job.interface.inputs.json[0].name: OUTPUT_DIR is a reserved variable name",
+			"error job.interface.mounts[0].name: ALLOCATED_GPUS is a reserved variable name",
+			"error job.interface.settings[0].name: ALLOCATED_CPUS is a reserved variable name",
+			"error job.interface.settings[1].name: name is empty",
+			"error job.errors[1].code: error code 1 is already declared at job.errors[0].code",
+			"error job.errors[1].category: unknown error category \"system\"; expected one of [job data]",
+		}},
+	}
+
+	for _, c := range cases {
+		findings := Lint(&c.seed)
+		if len(findings) != len(c.expected) {
+			t.Errorf("Lint returned %d findings %v, expected %d", len(findings), findings, len(c.expected))
+			continue
+		}
+		for i, f := range findings {
+			if f.String() != c.expected[i] {
+				t.Errorf("Lint returned %s, expected %s", f.String(), c.expected[i])
+			}
+		}
+	}
+}
+
+func TestAllocatedVariables(t *testing.T) {
+	seed := Seed{Job: Job{Resources: Resources{Scalar: []Scalar{{Name: "cpus"}, {Name: "gpus"}}}}}
+	result := fmt.Sprintf("%v", AllocatedVariables(&seed))
+	expected := "[ALLOCATED_CPUS ALLOCATED_MEM ALLOCATED_DISK ALLOCATED_SHAREDMEM ALLOCATED_GPUS]"
+	if result != expected {
+		t.Errorf("AllocatedVariables returned %s, expected %s", result, expected)
+	}
+}
@@ -0,0 +1,159 @@
+package objects
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/ngageoint/seed-common/util"
+)
+
+//ManifestNotFoundError is returned when a seed manifest file or image cannot be found
+type ManifestNotFoundError struct {
+	Source string
+	Err    error
+}
+
+func (e *ManifestNotFoundError) Error() string {
+	return fmt.Sprintf("ERROR: Seed manifest %s not found. %s", e.Source, e.Err.Error())
+}
+
+//ManifestParseError is returned when a seed manifest cannot be parsed
+type ManifestParseError struct {
+	Source string
+	Err    error
+}
+
+func (e *ManifestParseError) Error() string {
+	return fmt.Sprintf("ERROR: Error parsing seed manifest %s. %s", e.Source, e.Err.Error())
+}
+
+//EmptyManifestLabelError is returned when an image has no com.ngageoint.seed.manifest label
+// or compressed com.ngageoint.seed.manifest.gz label
+type EmptyManifestLabelError struct {
+	Image string
+}
+
+func (e *EmptyManifestLabelError) Error() string {
+	return fmt.Sprintf("ERROR: Image %s has an empty com.ngageoint.seed.manifest label", e.Image)
+}
+
+//SeedFromReader returns seed struct parsed from the given reader
+func SeedFromReader(r io.Reader) (Seed, error) {
+	var seed Seed
+	if err := json.NewDecoder(r).Decode(&seed); err != nil {
+		return Seed{}, &ManifestParseError{Source: "from reader", Err: err}
+	}
+
+	return seed, nil
+}
+
+//LoadSeedFromManifestFile returns seed struct parsed from seed file. YAML manifests
+// (seed.manifest.yaml or seed.manifest.yml) are converted to JSON before parsing so the same
+// defaults are applied.
+func LoadSeedFromManifestFile(seedFileName string) (Seed, error) {
+	seedbytes, err := readManifestSource(seedFileName)
+	if err != nil {
+		return Seed{}, err
+	}
+
+	seed, err := SeedFromReader(bytes.NewReader(seedbytes))
+	if perr, ok := err.(*ManifestParseError); ok {
+		perr.Source = seedFileName
+	}
+
+	return seed, err
+}
+
+//LoadSeedFromImageLabel returns seed parsed from the docker image LABEL
+func LoadSeedFromImageLabel(imageName string) (Seed, error) {
+	var errs, out bytes.Buffer
+	inspectCommand := exec.Command("docker", "inspect", "-f", "'{{json .Config.Labels}}'", imageName)
+	inspectCommand.Stderr = &errs
+	inspectCommand.Stdout = &out
+
+	err := inspectCommand.Run()
+
+	// check for errors on stderr first; it will likely have more explanation than cmd.Run
+	if errs.String() != "" {
+		if strings.Contains(errs.String(), "No such") {
+			return Seed{}, &ManifestNotFoundError{Source: imageName, Err: fmt.Errorf("%s", strings.TrimSpace(errs.String()))}
+		}
+		return Seed{}, fmt.Errorf("ERROR: Error executing docker inspect %s:\n%s", imageName, errs.String())
+	}
+	if err != nil {
+		return Seed{}, fmt.Errorf("ERROR: Error executing docker inspect %s. %s", imageName, err.Error())
+	}
+
+	seedStr, _, err := util.ManifestFromInspectLabels(out.String())
+	if err != nil {
+		return Seed{}, &ManifestParseError{Source: imageName, Err: err}
+	}
+	if seedStr == "" {
+		return Seed{}, &EmptyManifestLabelError{Image: imageName}
+	}
+
+	seed := Seed{}
+	if err = json.Unmarshal([]byte(seedStr), &seed); err != nil {
+		return Seed{}, &ManifestParseError{Source: imageName, Err: err}
+	}
+
+	return seed, nil
+}
+
+//LoadManifestLabel returns the seed.manifest.json as LABEL
+//  com.ngageoint.seed.manifest contents encoded with util.EncodeManifestLabel
+// YAML manifests are converted to the equivalent JSON first.
+func LoadManifestLabel(seedFileName string) (string, error) {
+	seedbytes, err := readManifestSource(seedFileName)
+	if err != nil {
+		return "", err
+	}
+
+	seed, err := util.EncodeManifestLabel(seedbytes)
+	if err != nil {
+		return "", &ManifestParseError{Source: seedFileName, Err: err}
+	}
+
+	return seed, nil
+}
+
+//LoadCompressedManifestLabel returns the seed.manifest.json as LABEL
+//  com.ngageoint.seed.manifest.gz contents encoded with util.EncodeCompressedManifestLabel
+// This is an alternative to LoadManifestLabel for very large manifests.
+func LoadCompressedManifestLabel(seedFileName string) (string, error) {
+	seedbytes, err := readManifestSource(seedFileName)
+	if err != nil {
+		return "", err
+	}
+
+	seed, err := util.EncodeCompressedManifestLabel(seedbytes)
+	if err != nil {
+		return "", &ManifestParseError{Source: seedFileName, Err: err}
+	}
+
+	return seed, nil
+}
+
+//readManifestSource reads a seed manifest file, converting YAML manifests to the equivalent JSON
+func readManifestSource(seedFileName string) ([]byte, error) {
+	seedbytes, err := ioutil.ReadFile(seedFileName)
+	if os.IsNotExist(err) {
+		return nil, &ManifestNotFoundError{Source: seedFileName, Err: err}
+	} else if err != nil {
+		return nil, err
+	}
+
+	if util.IsYamlManifest(seedFileName) {
+		if seedbytes, err = util.YamlToJson(seedbytes); err != nil {
+			return nil, &ManifestParseError{Source: seedFileName, Err: err}
+		}
+	}
+
+	return seedbytes, nil
+}
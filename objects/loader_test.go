@@ -0,0 +1,193 @@
+package objects
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ngageoint/seed-common/util"
+)
+
+func init() {
+	util.InitPrinter(util.Quiet, nil, nil)
+}
+
+func TestSeedFromReader(t *testing.T) {
+	cases := []struct {
+		manifest string
+		name     string
+		errStr   string
+	}{
+		{`{"seedVersion": "1.0.0", "job": {"name": "my-job"}}`, "my-job", ""},
+		{`{"seedVersion": "1.0.0", "job": {"name": `, "", "Error parsing seed manifest from reader"},
+		{`{"seedVersion": 1}`, "", "cannot unmarshal number"},
+	}
+
+	for _, c := range cases {
+		seed, err := SeedFromReader(strings.NewReader(c.manifest))
+		if seed.Job.Name != c.name {
+			t.Errorf("SeedFromReader(%q) returned job name %v, expected %v", c.manifest, seed.Job.Name, c.name)
+		}
+		if err == nil && c.errStr != "" {
+			t.Errorf("SeedFromReader(%q) did not return an error when one was expected: %v", c.manifest, c.errStr)
+		}
+		if err != nil {
+			if _, ok := err.(*ManifestParseError); !ok {
+				t.Errorf("SeedFromReader(%q) returned %T, expected *ManifestParseError", c.manifest, err)
+			}
+			if !strings.Contains(err.Error(), c.errStr) {
+				t.Errorf("SeedFromReader(%q) returned an error: %v\n expected %v", c.manifest, err, c.errStr)
+			}
+		}
+	}
+}
+
+func TestLoadSeedFromManifestFile(t *testing.T) {
+	cases := []struct {
+		file     string
+		name     string
+		notFound bool
+		parseErr bool
+	}{
+		{"../testdata/complete/seed.manifest.json", "my-job", false, false},
+		{"../testdata/complete/missing.manifest.json", "", true, false},
+		{"../testdata/complete/Dockerfile", "", false, true},
+	}
+
+	for _, c := range cases {
+		seed, err := LoadSeedFromManifestFile(c.file)
+		if seed.Job.Name != c.name {
+			t.Errorf("LoadSeedFromManifestFile(%q) returned job name %v, expected %v", c.file, seed.Job.Name, c.name)
+		}
+		_, notFound := err.(*ManifestNotFoundError)
+		if notFound != c.notFound {
+			t.Errorf("LoadSeedFromManifestFile(%q) returned %v, expected not found error: %v", c.file, err, c.notFound)
+		}
+		_, parseErr := err.(*ManifestParseError)
+		if parseErr != c.parseErr {
+			t.Errorf("LoadSeedFromManifestFile(%q) returned %v, expected parse error: %v", c.file, err, c.parseErr)
+		}
+	}
+}
+
+func TestLoadManifestLabel(t *testing.T) {
+	cases := []struct {
+		file   string
+		prefix string
+		errStr string
+	}{
+		{"../testdata/complete/seed.manifest.json", "\"{\\\"seedVersion\\\":\\\"1.0.0\\\"", ""},
+		{"../testdata/complete/missing.manifest.json", "", "not found"},
+		{"../testdata/complete/Dockerfile", "", "Error parsing seed manifest"},
+	}
+
+	for _, c := range cases {
+		label, err := LoadManifestLabel(c.file)
+		if !strings.HasPrefix(label, c.prefix) {
+			t.Errorf("LoadManifestLabel(%q) returned %v, expected prefix %v", c.file, label, c.prefix)
+		}
+		if err == nil && c.errStr != "" {
+			t.Errorf("LoadManifestLabel(%q) did not return an error when one was expected: %v", c.file, c.errStr)
+		}
+		if err != nil && !strings.Contains(err.Error(), c.errStr) {
+			t.Errorf("LoadManifestLabel(%q) returned an error: %v\n expected %v", c.file, err, c.errStr)
+		}
+	}
+}
+
+func TestLoadSeedFromYamlManifest(t *testing.T) {
+	jsonSeed, err := LoadSeedFromManifestFile("../testdata/complete/seed.manifest.json")
+	if err != nil {
+		t.Fatalf("Error loading JSON manifest: %v", err)
+	}
+	yamlSeed, err := LoadSeedFromManifestFile("../testdata/yaml/seed.manifest.yaml")
+	if err != nil {
+		t.Fatalf("Error loading YAML manifest: %v", err)
+	}
+
+	if yamlSeed.SeedVersion != jsonSeed.SeedVersion || yamlSeed.Job.Name != jsonSeed.Job.Name {
+		t.Errorf("YAML manifest returned %v %v, expected %v %v", yamlSeed.SeedVersion, yamlSeed.Job.Name,
+			jsonSeed.SeedVersion, jsonSeed.Job.Name)
+	}
+	if !reflect.DeepEqual(yamlSeed.Job.Interface, jsonSeed.Job.Interface) {
+		t.Errorf("YAML manifest interface %v does not match JSON manifest interface %v", yamlSeed.Job.Interface, jsonSeed.Job.Interface)
+	}
+	if !reflect.DeepEqual(yamlSeed.Job.Resources, jsonSeed.Job.Resources) {
+		t.Errorf("YAML manifest resources %v do not match JSON manifest resources %v", yamlSeed.Job.Resources, jsonSeed.Job.Resources)
+	}
+	if yamlSeed.Job.Errors[1].Category != "job" {
+		t.Errorf("YAML manifest error category %v, expected default of job", yamlSeed.Job.Errors[1].Category)
+	}
+
+	label, err := LoadManifestLabel("../testdata/yaml/seed.manifest.yaml")
+	if err != nil || !strings.Contains(label, `\"command\":\"\${INPUT_FILE} \${OUTPUT_DIR}\"`) {
+		t.Errorf("LoadManifestLabel returned %v, %v, expected escaped JSON label", label, err)
+	}
+}
+
+func TestLoadCompressedManifestLabel(t *testing.T) {
+	label, err := LoadCompressedManifestLabel("../testdata/complete/seed.manifest.json")
+	if err != nil {
+		t.Fatalf("LoadCompressedManifestLabel returned an error: %v", err)
+	}
+	plain, _ := LoadManifestLabel("../testdata/complete/seed.manifest.json")
+	if len(label) >= len(plain) {
+		t.Errorf("LoadCompressedManifestLabel returned %d bytes, expected fewer than the %d byte plain label", len(label), len(plain))
+	}
+
+	blob := ioutil.NopCloser(strings.NewReader(`{"config": {"Labels": {"com.ngageoint.seed.manifest.gz": "` + label + `"}}}`))
+	manifest, err := GetSeedManifestFromBlob(blob)
+	if err != nil {
+		t.Fatalf("GetSeedManifestFromBlob returned an error: %v", err)
+	}
+	seed, err := SeedFromManifestString(manifest)
+	if err != nil || seed.Job.Name != "my-job" {
+		t.Errorf("GetSeedManifestFromBlob returned manifest for %v, %v, expected my-job", seed.Job.Name, err)
+	}
+
+	if _, err = LoadCompressedManifestLabel("../testdata/complete/Dockerfile"); err == nil {
+		t.Errorf("LoadCompressedManifestLabel did not return an error for an invalid manifest")
+	}
+}
+
+func TestGetImageNameFromManifest(t *testing.T) {
+	printed := []string{}
+	util.PrintUtil = func(format string, args ...interface{}) {
+		printed = append(printed, fmt.Sprintf(format, args...))
+	}
+	defer util.InitPrinter(util.Quiet, nil, nil)
+
+	cases := []struct {
+		manifest  string
+		directory string
+		image     string
+		errStr    string
+	}{
+		{"", "../testdata/complete", "my-job-0.1.0-seed:0.1.0", ""},
+		{"seed.manifest.json", "../testdata/complete", "my-job-0.1.0-seed:0.1.0", ""},
+		{"missing.manifest.json", "../testdata/complete", "", "Seed manifest not found"},
+		{"", "../testdata", "", "cannot be found"},
+		{"Dockerfile", "../testdata/complete", "", "Error parsing seed manifest"},
+	}
+
+	for _, c := range cases {
+		printed = printed[:0]
+		image, err := GetImageNameFromManifest(c.manifest, c.directory)
+		if image != c.image {
+			t.Errorf("GetImageNameFromManifest(%q, %q) returned %v, expected %v", c.manifest, c.directory, image, c.image)
+		}
+		if err == nil && c.errStr != "" {
+			t.Errorf("GetImageNameFromManifest(%q, %q) did not return an error when one was expected: %v", c.manifest, c.directory, c.errStr)
+		}
+		if err != nil && (c.errStr == "" || !strings.Contains(err.Error(), c.errStr)) {
+			t.Errorf("GetImageNameFromManifest(%q, %q) returned an error: %v\n expected %v", c.manifest, c.directory, err, c.errStr)
+		}
+		for _, line := range printed {
+			if strings.HasPrefix(line, "ERROR") {
+				t.Errorf("GetImageNameFromManifest(%q, %q) printed the error %q", c.manifest, c.directory, line)
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package objects
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ngageoint/seed-common/util"
+)
+
+//MediaTypePolicy defines how files that do not match their declared media types are handled
+type MediaTypePolicy int
+
+const (
+	//MediaTypeIgnore skips media type verification
+	MediaTypeIgnore MediaTypePolicy = iota
+
+	//MediaTypeWarn reports mismatches as warnings
+	MediaTypeWarn
+
+	//MediaTypeEnforce reports mismatches as errors; inputs are rejected and outputs are invalid
+	MediaTypeEnforce
+)
+
+//MediaTypeMismatch describes a file whose detected media type is not one of the declared types
+type MediaTypeMismatch struct {
+	Severity Severity
+	Name     string
+	File     string
+	Expected []string
+	Detected string
+}
+
+//String formats the mismatch as severity name: file is detected, expected expected
+func (m MediaTypeMismatch) String() string {
+	return fmt.Sprintf("%s %s: %s", m.Severity, m.Name, m.message())
+}
+
+func (m MediaTypeMismatch) message() string {
+	return fmt.Sprintf("%s is %s, expected %s", m.File, m.Detected, strings.Join(m.Expected, " or "))
+}
+
+//VerifyInputMediaTypes checks the given input files, keyed by input name, against the media types
+// declared by the seed's Interface.Inputs.Files. Inputs that declare no media types are not checked.
+func VerifyInputMediaTypes(seed *Seed, files map[string][]string, policy MediaTypePolicy) ([]MediaTypeMismatch, error) {
+	declared := make(map[string][]string)
+	for _, f := range seed.Job.Interface.Inputs.Files {
+		declared[f.Name] = f.MediaTypes
+	}
+	return verifyMediaTypes(declared, files, policy)
+}
+
+//VerifyOutputMediaTypes checks the given output files, keyed by output name (i.e.
+// OutputResult.Files), against the media type declared by the seed's Interface.Outputs.Files.
+// Outputs that declare no media type are not checked.
+func VerifyOutputMediaTypes(seed *Seed, files map[string][]string, policy MediaTypePolicy) ([]MediaTypeMismatch, error) {
+	declared := make(map[string][]string)
+	for _, f := range seed.Job.Interface.Outputs.Files {
+		if f.MediaType != "" {
+			declared[f.Name] = []string{f.MediaType}
+		}
+	}
+	return verifyMediaTypes(declared, files, policy)
+}
+
+func verifyMediaTypes(declared, files map[string][]string, policy MediaTypePolicy) ([]MediaTypeMismatch, error) {
+	mismatches := []MediaTypeMismatch{}
+	if policy == MediaTypeIgnore {
+		return mismatches, nil
+	}
+	severity := SeverityWarning
+	if policy == MediaTypeEnforce {
+		severity = SeverityError
+	}
+
+	for _, name := range sortedListKeys(files) {
+		expected := declared[name]
+		if len(expected) == 0 {
+			continue
+		}
+		for _, file := range files[name] {
+			detected, err := util.DetectMediaType(file)
+			if err != nil {
+				return mismatches, err
+			}
+			matches := false
+			for _, mediaType := range expected {
+				matches = matches || util.MediaTypeMatches(detected, mediaType)
+			}
+			if !matches {
+				mismatches = append(mismatches, MediaTypeMismatch{severity, name, file, expected, detected})
+			}
+		}
+	}
+
+	return mismatches, nil
+}
@@ -0,0 +1,62 @@
+package objects
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestVerifyMediaTypes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mediatypes")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	hdf5 := filepath.Join(dir, "in.h5")
+	tiff := filepath.Join(dir, "in.tif")
+	csv := filepath.Join(dir, "outfile.csv")
+	ioutil.WriteFile(hdf5, []byte("\x89HDF\r\n\x1a\n"), 0644)
+	ioutil.WriteFile(tiff, []byte("II*\x00"), 0644)
+	ioutil.WriteFile(csv, []byte("a,b\n"), 0644)
+
+	seed, err := LoadSeedFromManifestFile("../testdata/complete/seed.manifest.json")
+	if err != nil {
+		t.Fatalf("Error loading manifest: %v", err)
+	}
+
+	inputCases := []struct {
+		files      map[string][]string
+		policy     MediaTypePolicy
+		mismatches []MediaTypeMismatch
+	}{
+		{map[string][]string{"INPUT_FILE": {hdf5}}, MediaTypeEnforce, []MediaTypeMismatch{}},
+		{map[string][]string{"INPUT_FILE": {tiff}}, MediaTypeIgnore, []MediaTypeMismatch{}},
+		{map[string][]string{"INPUT_FILE": {tiff}}, MediaTypeWarn, []MediaTypeMismatch{
+			{SeverityWarning, "INPUT_FILE", tiff, []string{"image/x-hdf5-image"}, "image/tiff"}}},
+		{map[string][]string{"INPUT_FILE": {tiff}, "UNDECLARED": {tiff}}, MediaTypeEnforce, []MediaTypeMismatch{
+			{SeverityError, "INPUT_FILE", tiff, []string{"image/x-hdf5-image"}, "image/tiff"}}},
+	}
+	for _, c := range inputCases {
+		mismatches, err := VerifyInputMediaTypes(&seed, c.files, c.policy)
+		if err != nil || !reflect.DeepEqual(mismatches, c.mismatches) {
+			t.Errorf("VerifyInputMediaTypes(%v, %v) returned %v, %v, expected %v", c.files, c.policy, mismatches, err, c.mismatches)
+		}
+	}
+
+	outputs := map[string][]string{"output_file_tiffs": {tiff, hdf5}, "output_file_csv": {csv}}
+	mismatches, err := VerifyOutputMediaTypes(&seed, outputs, MediaTypeEnforce)
+	expected := []MediaTypeMismatch{{SeverityError, "output_file_tiffs", hdf5, []string{"image/tiff"}, "image/x-hdf5-image"}}
+	if err != nil || !reflect.DeepEqual(mismatches, expected) {
+		t.Errorf("VerifyOutputMediaTypes returned %v, %v, expected %v", mismatches, err, expected)
+	}
+	if str := expected[0].String(); str != "error output_file_tiffs: "+hdf5+" is image/x-hdf5-image, expected image/tiff" {
+		t.Errorf("MediaTypeMismatch.String() returned %v", str)
+	}
+
+	if _, err = VerifyInputMediaTypes(&seed, map[string][]string{"INPUT_FILE": {filepath.Join(dir, "missing")}}, MediaTypeWarn); err == nil {
+		t.Errorf("VerifyInputMediaTypes did not return an error for a missing file")
+	}
+}
@@ -0,0 +1,175 @@
+package objects
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ngageoint/seed-common/constants"
+)
+
+//OutputViolation describes a way in which a job's outputs did not honor its manifest
+type OutputViolation struct {
+	Name    string
+	Message string
+}
+
+//String formats the violation as name: message
+func (v OutputViolation) String() string {
+	return fmt.Sprintf("%s: %s", v.Name, v.Message)
+}
+
+//OutputResult holds the outputs captured from a job's output directory
+type OutputResult struct {
+	Files      map[string][]string
+	Json       map[string]interface{}
+	Violations []OutputViolation
+}
+
+//Valid checks if the outputs honored the manifest
+func (r *OutputResult) Valid() bool {
+	return len(r.Violations) == 0
+}
+
+func (r *OutputResult) violation(name, format string, args ...interface{}) {
+	r.Violations = append(r.Violations, OutputViolation{Name: name, Message: fmt.Sprintf(format, args...)})
+}
+
+//CollectOutputs captures the files and json values declared by the seed's outputs from the given
+// output directory and checks them against the manifest. Errors reading the output directory are
+// reported as violations rather than returned.
+func CollectOutputs(seed *Seed, outputDir string) OutputResult {
+	result := OutputResult{
+		Files: make(map[string][]string),
+		Json:  make(map[string]interface{}),
+	}
+	outputs := seed.Job.Interface.Outputs
+
+	for _, f := range outputs.Files {
+		matches, err := globDir(outputDir, f.Pattern)
+		if err != nil {
+			result.violation(f.Name, "invalid pattern %s. %s", f.Pattern, err.Error())
+			continue
+		}
+		sort.Strings(matches)
+
+		if len(matches) == 0 {
+			if f.Required {
+				result.violation(f.Name, "no files match required pattern %s", f.Pattern)
+			}
+			continue
+		}
+		if !f.Multiple && len(matches) > 1 {
+			result.violation(f.Name, "%d files match pattern %s but multiple is false", len(matches), f.Pattern)
+		}
+		result.Files[f.Name] = matches
+	}
+
+	if len(outputs.JSON) == 0 {
+		return result
+	}
+
+	values := make(map[string]interface{})
+	outputsFile := filepath.Join(outputDir, constants.SeedOutputsFileName)
+	file, err := os.Open(outputsFile)
+	if err == nil {
+		defer file.Close()
+		decoder := json.NewDecoder(file)
+		decoder.UseNumber()
+		if err = decoder.Decode(&values); err != nil {
+			result.violation(constants.SeedOutputsFileName, "unable to parse %s. %s", outputsFile, err.Error())
+		}
+	} else if !os.IsNotExist(err) {
+		result.violation(constants.SeedOutputsFileName, "unable to read %s. %s", outputsFile, err.Error())
+	}
+
+	for _, j := range outputs.JSON {
+		key := j.Key
+		if key == "" {
+			key = j.Name
+		}
+
+		value, ok := values[key]
+		if !ok {
+			if j.Required {
+				result.violation(j.Name, "required key %s not found in %s", key, constants.SeedOutputsFileName)
+			}
+			continue
+		}
+
+		converted, ok := convertJsonType(value, j.Type)
+		if !ok {
+			result.violation(j.Name, "value %v of key %s is not of type %s", value, key, j.Type)
+			continue
+		}
+		result.Json[j.Name] = converted
+	}
+
+	return result
+}
+
+//globDir returns the paths in dir matching the pattern like filepath.Glob, but matches the pattern
+// against paths relative to dir so pattern characters in dir itself (i.e. /data/run[1]) are literal
+func globDir(dir, pattern string) ([]string, error) {
+	pattern = filepath.Clean(pattern)
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+	depth := strings.Count(pattern, string(filepath.Separator)) + 1
+
+	matches := []string{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		if matched, _ := filepath.Match(pattern, rel); matched {
+			matches = append(matches, path)
+		}
+		// a pattern never matches deeper than its own number of path elements
+		if info.IsDir() && strings.Count(rel, string(filepath.Separator))+1 >= depth {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return matches, nil
+	}
+	return matches, err
+}
+
+//convertJsonType checks that a value decoded with UseNumber matches the given seed json type and
+// converts numbers into int64 or float64 accordingly
+func convertJsonType(value interface{}, jsonType string) (interface{}, bool) {
+	switch jsonType {
+	case "string":
+		_, ok := value.(string)
+		return value, ok
+	case "boolean":
+		_, ok := value.(bool)
+		return value, ok
+	case "integer":
+		if n, ok := value.(json.Number); ok {
+			i, err := n.Int64()
+			return i, err == nil
+		}
+	case "number":
+		if n, ok := value.(json.Number); ok {
+			f, err := n.Float64()
+			return f, err == nil
+		}
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return value, ok
+	case "array":
+		_, ok := value.([]interface{})
+		return value, ok
+	}
+	return value, false
+}
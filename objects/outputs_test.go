@@ -0,0 +1,74 @@
+package objects
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCollectOutputs(t *testing.T) {
+	seed, err := LoadSeedFromManifestFile("../testdata/complete/seed.manifest.json")
+	if err != nil {
+		t.Fatalf("Error loading complete manifest: %v", err)
+	}
+	seed.Job.Interface.Outputs.JSON = append(seed.Job.Interface.Outputs.JSON,
+		OutJson{Name: "ratio", Type: "number", Required: false},
+		OutJson{Name: "labels", Type: "array", Required: false})
+
+	cases := []struct {
+		files      map[string]string
+		captured   string
+		json       string
+		violations string
+	}{
+		{map[string]string{
+			"outfile1.tif":      "",
+			"outfile2.tif":      "",
+			"seed.outputs.json": `{"cellCount": 12, "ratio": 0.5, "labels": ["a"]}`,
+		}, "map[output_file_tiffs:[outfile1.tif outfile2.tif]]", "map[cell_count:12 labels:[a] ratio:0.5]", "[]"},
+		{map[string]string{
+			"outfile1.csv":      "",
+			"outfile2.csv":      "",
+			"seed.outputs.json": `{"cellCount": 1.5, "ratio": "half"}`,
+		}, "map[output_file_csv:[outfile1.csv outfile2.csv]]", "map[]",
+			"[output_file_tiffs: no files match required pattern outfile*.tif output_file_csv: 2 files match pattern outfile*.csv but multiple is false cell_count: value 1.5 of key cellCount is not of type integer ratio: value half of key ratio is not of type number]"},
+		{map[string]string{
+			"outfile1.tif": "",
+		}, "map[output_file_tiffs:[outfile1.tif]]", "map[]",
+			"[cell_count: required key cellCount not found in seed.outputs.json]"},
+	}
+
+	for _, c := range cases {
+		// pattern characters in the output directory must be matched literally
+		dir, err := ioutil.TempDir("", "seed-outputs[*?]")
+		if err != nil {
+			t.Fatalf("Error creating output directory: %v", err)
+		}
+		defer os.RemoveAll(dir)
+		for name, content := range c.files {
+			ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		}
+
+		result := CollectOutputs(&seed, dir)
+		for name, files := range result.Files {
+			for i, f := range files {
+				result.Files[name][i], _ = filepath.Rel(dir, f)
+			}
+		}
+
+		if fmt.Sprintf("%v", result.Files) != c.captured {
+			t.Errorf("CollectOutputs returned files %v, expected %v", result.Files, c.captured)
+		}
+		if fmt.Sprintf("%v", result.Json) != c.json {
+			t.Errorf("CollectOutputs returned json %v, expected %v", result.Json, c.json)
+		}
+		if fmt.Sprintf("%v", result.Violations) != c.violations {
+			t.Errorf("CollectOutputs returned violations %v, expected %v", result.Violations, c.violations)
+		}
+		if result.Valid() != (c.violations == "[]") {
+			t.Errorf("CollectOutputs returned valid %v for violations %v", result.Valid(), result.Violations)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package objects
+
+import (
+	"strconv"
+
+	"github.com/ngageoint/seed-common/util"
+)
+
+//CalculateResources returns the effective value of each scalar resource declared by the seed for a
+// run whose input files total inputSizeMiB. The effective value is value + inputMultiplier * input size.
+func CalculateResources(seed *Seed, inputSizeMiB float64) []Scalar {
+	resources := []Scalar{}
+	for _, scalar := range seed.Job.Resources.Scalar {
+		value := scalar.Value + scalar.InputMultiplier*inputSizeMiB
+		resources = append(resources, Scalar{Name: scalar.Name, Value: value})
+	}
+	return resources
+}
+
+//CalculateResourcesForFiles returns the effective scalar resources for a run using the given input files
+func CalculateResourcesForFiles(seed *Seed, inputFiles []string) ([]Scalar, error) {
+	size, err := util.TotalFileSizeMiB(inputFiles)
+	if err != nil {
+		return nil, err
+	}
+	return CalculateResources(seed, size), nil
+}
+
+//GetResource returns the effective value of the named resource and whether it was found
+func GetResource(resources []Scalar, name string) (float64, bool) {
+	for _, scalar := range resources {
+		if scalar.Name == name {
+			return scalar.Value, true
+		}
+	}
+	return 0, false
+}
+
+//AllocatedEnvironment returns the ALLOCATED_* environment variables for the given resources,
+// i.e. ALLOCATED_CPUS, ALLOCATED_MEM, ALLOCATED_DISK and ALLOCATED_SHAREDMEM. Variables are only
+// set for the resources given; no defaults are assumed for the others.
+func AllocatedEnvironment(resources []Scalar) map[string]string {
+	env := make(map[string]string)
+	for _, scalar := range resources {
+		name := "ALLOCATED_" + util.GetNormalizedVariable(scalar.Name)
+		env[name] = strconv.FormatFloat(scalar.Value, 'f', -1, 64)
+	}
+	return env
+}
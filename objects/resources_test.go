@@ -0,0 +1,57 @@
+package objects
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCalculateResources(t *testing.T) {
+	seed, err := LoadSeedFromManifestFile("../testdata/complete/seed.manifest.json")
+	if err != nil {
+		t.Fatalf("Error loading complete manifest: %v", err)
+	}
+	seed.Job.Resources.Scalar = append(seed.Job.Resources.Scalar, Scalar{Name: "gpus", Value: 1})
+
+	cases := []struct {
+		inputSize float64
+		resources string
+		env       string
+	}{
+		{0, "[{cpus 10 0} {mem 10240 0} {sharedMem 0 0} {disk 10 0} {gpus 1 0}]",
+			"map[ALLOCATED_CPUS:10 ALLOCATED_DISK:10 ALLOCATED_GPUS:1 ALLOCATED_MEM:10240 ALLOCATED_SHAREDMEM:0]"},
+		{2.5, "[{cpus 10 0} {mem 10240 0} {sharedMem 0 0} {disk 20 0} {gpus 1 0}]",
+			"map[ALLOCATED_CPUS:10 ALLOCATED_DISK:20 ALLOCATED_GPUS:1 ALLOCATED_MEM:10240 ALLOCATED_SHAREDMEM:0]"},
+	}
+
+	for _, c := range cases {
+		resources := CalculateResources(&seed, c.inputSize)
+		if fmt.Sprintf("%v", resources) != c.resources {
+			t.Errorf("CalculateResources(%v) returned %v, expected %v", c.inputSize, resources, c.resources)
+		}
+		env := AllocatedEnvironment(resources)
+		if fmt.Sprintf("%v", env) != c.env {
+			t.Errorf("AllocatedEnvironment(%v) returned %v, expected %v", resources, env, c.env)
+		}
+	}
+
+	if _, err := CalculateResourcesForFiles(&seed, []string{"../testdata/missing"}); err == nil {
+		t.Errorf("CalculateResourcesForFiles did not return an error for a missing file")
+	}
+	if disk, ok := GetResource(CalculateResources(&seed, 1), "disk"); !ok || disk != 14 {
+		t.Errorf("GetResource returned %v, %v, expected 14, true", disk, ok)
+	}
+
+
+	// resources the seed does not declare are not allocated
+	seed.Job.Resources.Scalar = []Scalar{{Name: "mem", Value: 512}}
+	resources := CalculateResources(&seed, 2.5)
+	expected := "[{mem 512 0}]"
+	if fmt.Sprintf("%v", resources) != expected {
+		t.Errorf("CalculateResources returned %v, expected %v", resources, expected)
+	}
+	env := AllocatedEnvironment(resources)
+	expected = "map[ALLOCATED_MEM:512]"
+	if fmt.Sprintf("%v", env) != expected {
+		t.Errorf("AllocatedEnvironment returned %v, expected %v", env, expected)
+	}
+}
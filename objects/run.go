@@ -0,0 +1,389 @@
+package objects
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ngageoint/seed-common/util"
+)
+
+const (
+	//ContainerInputDir is the directory input files are mounted under in the container. Each input
+	// is mounted read-only at ContainerInputDir/<NORMALIZED_NAME>/<file name>.
+	ContainerInputDir = "/seed/inputs"
+
+	//ContainerOutputDir is the directory the run's output directory is mounted at in the container
+	ContainerOutputDir = "/seed/outputs"
+)
+
+//RunRequest holds the values for a single run of a seed image. All maps are keyed by the name
+// declared in the manifest.
+type RunRequest struct {
+	Image     string                 //image to run; defaults to BuildImageName
+	Name      string                 //optional container name
+	Inputs    map[string][]string    //host paths of the input files
+	Json      map[string]interface{} //json input values; strings are coerced into the declared type
+	Settings  map[string]string
+	Mounts    map[string]string //host path bound to each mount
+	OutputDir string            //host output directory
+	Resources []Scalar          //effective resources; calculated from the input files when nil
+	Multiple  MultipleMode
+	Remove    bool                //remove the container when it exits
+	MediaType MediaTypePolicy     //how RunJob handles inputs and outputs that do not match their media types
+	Secrets   util.SecretProvider //resolves secret settings that are not given in Settings
+}
+
+//RunRequestError is returned when a run request does not satisfy the seed's interface
+type RunRequestError struct {
+	Violations []InputViolation
+}
+
+func (e *RunRequestError) Error() string {
+	problems := []string{}
+	for _, v := range e.Violations {
+		problems = append(problems, v.String())
+	}
+	return fmt.Sprintf("ERROR: Invalid run request:\n%s", strings.Join(problems, "\n"))
+}
+
+//DockerRunArgs converts the seed and run request into the arguments of a docker run command:
+//  - input files are bind-mounted read-only under ContainerInputDir
+//  - the output directory is bind-mounted at ContainerOutputDir
+//  - mounts are bound at their declared path honoring Mount.Mode
+//  - paths containing a colon cannot be bound with -v and are reported as violations
+//  - the cpus, mem and sharedMem scalars are mapped to --cpus, --memory and --shm-size (MiB)
+//  - inputs, json inputs, mounts, settings, OUTPUT_DIR and ALLOCATED_* are set as normalized
+//    environment variables
+//  - secret settings are resolved through req.Secrets when not given in req.Settings and are
+//    passed by name only (-e NAME); RunJob sets them in the environment of the docker client
+//  - the expanded Interface.Command is appended after the image. References to secret settings
+//    are left as literal ${NAME} references for the container to resolve from its environment,
+//    so secret values never appear in the arguments.
+// The returned arguments do not include the docker executable itself.
+func DockerRunArgs(seed *Seed, req RunRequest) ([]string, error) {
+	iface := seed.Job.Interface
+	violations := []InputViolation{}
+	volumes := []string{}
+	env := make(map[string]string)
+
+	containerFiles := make(map[string][]string)
+	for _, f := range iface.Inputs.Files {
+		files := req.Inputs[f.Name]
+		if len(files) == 0 {
+			if f.Required {
+				violations = append(violations, InputViolation{f.Name, "required input not provided"})
+			}
+			continue
+		}
+		if len(files) > 1 && !f.Multiple {
+			violations = append(violations, InputViolation{f.Name, fmt.Sprintf("%d files provided for a single file input", len(files))})
+		}
+
+		dir := path.Join(ContainerInputDir, util.GetNormalizedVariable(f.Name))
+		for _, file := range files {
+			abs, err := filepath.Abs(file)
+			if err != nil {
+				violations = append(violations, InputViolation{f.Name, err.Error()})
+				continue
+			}
+			containerFile := path.Join(dir, filepath.Base(abs))
+			if util.ContainsString(containerFiles[f.Name], containerFile) {
+				violations = append(violations, InputViolation{f.Name, fmt.Sprintf("more than one file named %s", filepath.Base(abs))})
+				continue
+			}
+			volume, err := volumeSpec(abs, containerFile, "ro")
+			if err != nil {
+				violations = append(violations, InputViolation{f.Name, err.Error()})
+				continue
+			}
+			containerFiles[f.Name] = append(containerFiles[f.Name], containerFile)
+			volumes = append(volumes, volume)
+		}
+	}
+	for _, name := range sortedListKeys(req.Inputs) {
+		if !declaresInputFile(seed, name) {
+			violations = append(violations, InputViolation{name, "not declared as an input file"})
+		}
+	}
+
+	jsonValues, jsonViolations := ValidateJsonInputs(seed, req.Json)
+	violations = append(violations, jsonViolations...)
+
+	for _, m := range iface.Mounts {
+		hostPath, ok := req.Mounts[m.Name]
+		if !ok || hostPath == "" {
+			violations = append(violations, InputViolation{m.Name, "no host path provided for mount"})
+			continue
+		}
+		abs, err := filepath.Abs(hostPath)
+		if err != nil {
+			violations = append(violations, InputViolation{m.Name, err.Error()})
+			continue
+		}
+		mode := m.Mode
+		if mode == "" {
+			mode = "ro"
+		}
+		volume, err := volumeSpec(abs, m.Path, mode)
+		if err != nil {
+			violations = append(violations, InputViolation{m.Name, err.Error()})
+			continue
+		}
+		volumes = append(volumes, volume)
+		env[util.GetNormalizedVariable(m.Name)] = m.Path
+	}
+
+	settings, settingViolations := resolveSettings(seed, req)
+	violations = append(violations, settingViolations...)
+	secrets := make(map[string]bool)
+	commandSettings := make(map[string]string)
+	for _, s := range iface.Settings {
+		name := util.GetNormalizedVariable(s.Name)
+		env[name] = settings[s.Name]
+		secrets[name] = s.Secret
+		if s.Secret {
+			commandSettings[s.Name] = "${" + name + "}"
+		} else {
+			commandSettings[s.Name] = settings[s.Name]
+		}
+	}
+
+	if req.OutputDir == "" {
+		violations = append(violations, InputViolation{OutputDirVariable, "no output directory provided"})
+	} else if abs, err := filepath.Abs(req.OutputDir); err != nil {
+		violations = append(violations, InputViolation{OutputDirVariable, err.Error()})
+	} else if volume, err := volumeSpec(abs, ContainerOutputDir, ""); err != nil {
+		violations = append(violations, InputViolation{OutputDirVariable, err.Error()})
+	} else {
+		volumes = append(volumes, volume)
+	}
+
+	if len(violations) > 0 {
+		return nil, &RunRequestError{Violations: violations}
+	}
+
+	resources := req.Resources
+	if resources == nil {
+		hostFiles := []string{}
+		for _, files := range req.Inputs {
+			hostFiles = append(hostFiles, files...)
+		}
+		var err error
+		if resources, err = CalculateResourcesForFiles(seed, hostFiles); err != nil {
+			return nil, err
+		}
+	}
+	allocated := AllocatedEnvironment(resources)
+
+	values := CommandValues{
+		Files:     containerFiles,
+		Json:      jsonValues,
+		Settings:  commandSettings,
+		OutputDir: ContainerOutputDir,
+		Env:       allocated,
+		Multiple:  req.Multiple,
+	}
+	command, undeclared := ExpandCommand(seed, values)
+	if len(undeclared) > 0 {
+		util.PrintUtil("WARN: The command references undeclared variables: %s\n", strings.Join(undeclared, ", "))
+	}
+
+	// the environment holds the same values the command was expanded with
+	vars := commandVariables(seed, values)
+	for _, f := range iface.Inputs.Files {
+		name := util.GetNormalizedVariable(f.Name)
+		env[name] = strings.Join(vars[name].values, " ")
+	}
+	for _, j := range iface.Inputs.Json {
+		if value, ok := jsonValues[j.Name]; ok {
+			env[util.GetNormalizedVariable(j.Name)] = jsonString(value)
+		}
+	}
+	for name, value := range allocated {
+		env[name] = value
+	}
+	env[OutputDirVariable] = ContainerOutputDir
+
+	args := []string{"run"}
+	if req.Remove {
+		args = append(args, "--rm")
+	}
+	if req.Name != "" {
+		args = append(args, "--name", req.Name)
+	}
+	if cpus, ok := GetResource(resources, "cpus"); ok && cpus > 0 {
+		args = append(args, "--cpus", strconv.FormatFloat(cpus, 'f', -1, 64))
+	}
+	if mem, ok := GetResource(resources, "mem"); ok && mem > 0 {
+		args = append(args, "--memory", mebibytes(mem))
+	}
+	if shm, ok := GetResource(resources, "sharedMem"); ok && shm > 0 {
+		args = append(args, "--shm-size", mebibytes(shm))
+	}
+	for _, volume := range volumes {
+		args = append(args, "-v", volume)
+	}
+	for _, name := range sortedKeys(env) {
+		if secrets[name] {
+			args = append(args, "-e", name)
+		} else {
+			args = append(args, "-e", name+"="+env[name])
+		}
+	}
+
+	image := req.Image
+	if image == "" {
+		image = BuildImageName(seed)
+	}
+	args = append(args, image)
+	args = append(args, command...)
+
+	return args, nil
+}
+
+//DryRun builds the docker run command for the seed and run request and prints it instead of
+// running it. The printed command is returned.
+func DryRun(seed *Seed, req RunRequest) (string, error) {
+	settings, violations := resolveSettings(seed, req)
+	if len(violations) > 0 {
+		return "", &RunRequestError{Violations: violations}
+	}
+	req.Settings, req.Secrets = settings, nil
+	defer registerSecretSettings(seed, settings)()
+
+	args, err := DockerRunArgs(seed, req)
+	if err != nil {
+		return "", err
+	}
+	command := util.Redact(FormatCommand("docker", args))
+	util.PrintUtil("INFO: Dry run: %s\n", command)
+	return command, nil
+}
+
+//resolveSettings returns the value of each setting, resolving secret settings that are not given
+// in req.Settings through req.Secrets. Settings that are not declared in the manifest are reported
+// as violations.
+func resolveSettings(seed *Seed, req RunRequest) (map[string]string, []InputViolation) {
+	settings := make(map[string]string)
+	violations := []InputViolation{}
+	for _, s := range seed.Job.Interface.Settings {
+		value, ok := req.Settings[s.Name]
+		if !ok && s.Secret && req.Secrets != nil {
+			var err error
+			if value, err = req.Secrets.GetSecret(s.Name); util.IsSecretNotFound(err) {
+				violations = append(violations, InputViolation{s.Name, "secret not found"})
+			} else if err != nil {
+				violations = append(violations, InputViolation{s.Name, "unable to resolve secret. " + err.Error()})
+			}
+		}
+		settings[s.Name] = value
+	}
+	for _, name := range sortedKeys(req.Settings) {
+		if _, ok := settings[name]; !ok {
+			violations = append(violations, InputViolation{name, "not declared as a setting"})
+		}
+	}
+	return settings, violations
+}
+
+//registerSecretSettings registers the values of the seed's secret settings for redaction and
+// returns a function unregistering them
+func registerSecretSettings(seed *Seed, settings map[string]string) func() {
+	unregister := []func(){}
+	for _, s := range seed.Job.Interface.Settings {
+		if s.Secret {
+			unregister = append(unregister, util.RegisterSecret(settings[s.Name]))
+		}
+	}
+	return func() {
+		for _, f := range unregister {
+			f()
+		}
+	}
+}
+
+//secretEnvironment returns the NAME=value pairs of the secret settings for the environment of the
+// docker client, which passes them on to the container
+func secretEnvironment(seed *Seed, settings map[string]string) []string {
+	env := []string{}
+	for _, s := range seed.Job.Interface.Settings {
+		if s.Secret {
+			env = append(env, util.GetNormalizedVariable(s.Name)+"="+settings[s.Name])
+		}
+	}
+	return env
+}
+
+//FormatCommand formats a command and its arguments as a shell command line, quoting arguments
+// where necessary
+func FormatCommand(name string, args []string) string {
+	quoted := []string{shellQuote(name)}
+	for _, arg := range args {
+		quoted = append(quoted, shellQuote(arg))
+	}
+	return strings.Join(quoted, " ")
+}
+
+func shellQuote(arg string) string {
+	if arg != "" && strings.IndexFunc(arg, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:=,+@%", r))
+	}) < 0 {
+		return arg
+	}
+	return "'" + strings.Replace(arg, "'", `'\''`, -1) + "'"
+}
+
+//mebibytes formats a size in MiB for docker's --memory and --shm-size options, rounding up
+func mebibytes(size float64) string {
+	return strconv.FormatInt(int64(math.Ceil(size)), 10) + "m"
+}
+
+//volumeSpec formats a host:container[:mode] bind for docker's -v option. Paths containing a colon
+// cannot be told apart from the mode and are rejected.
+func volumeSpec(hostPath, containerPath, mode string) (string, error) {
+	for _, p := range []string{hostPath, containerPath} {
+		if strings.Contains(p, ":") {
+			msg := fmt.Sprintf("path %s cannot be mounted because it contains a colon", p)
+			return "", errors.New(msg)
+		}
+	}
+	if mode == "" {
+		return hostPath + ":" + containerPath, nil
+	}
+	return hostPath + ":" + containerPath + ":" + mode, nil
+}
+
+//sortedKeys returns the keys of the map in sorted order
+func sortedKeys(m map[string]string) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+//sortedListKeys returns the keys of the map in sorted order
+func sortedListKeys(m map[string][]string) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func declaresInputFile(seed *Seed, name string) bool {
+	for _, f := range seed.Job.Interface.Inputs.Files {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
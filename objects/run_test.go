@@ -0,0 +1,132 @@
+package objects
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ngageoint/seed-common/util"
+)
+
+func TestDockerRunArgs(t *testing.T) {
+	seed, err := LoadSeedFromManifestFile("../testdata/complete/seed.manifest.json")
+	if err != nil {
+		t.Fatalf("Error loading manifest: %v", err)
+	}
+	seed.Job.Interface.Mounts = append(seed.Job.Interface.Mounts, Mount{Name: "SCRATCH", Path: "/scratch", Mode: "rw"})
+	resources := []Scalar{{"cpus", 1.5, 0}, {"mem", 512.2, 0}, {"sharedMem", 0, 0}, {"disk", 10, 0}}
+
+	cases := []struct {
+		req    RunRequest
+		args   []string
+		errStr string
+	}{
+		{RunRequest{
+			Inputs:    map[string][]string{"INPUT_FILE": {"/data/in.h5"}},
+			Settings:  map[string]string{"DB_HOST": "db.example.com"},
+			Mounts:    map[string]string{"MOUNT_PATH": "/host/mount", "SCRATCH": "/host/scratch"},
+			OutputDir: "/host/out",
+			Resources: resources,
+			Name:      "run-1",
+			Remove:    true,
+		}, []string{"run", "--rm", "--name", "run-1", "--cpus", "1.5", "--memory", "513m",
+			"-v", "/data/in.h5:/seed/inputs/INPUT_FILE/in.h5:ro",
+			"-v", "/host/mount:/the/container/path:ro",
+			"-v", "/host/scratch:/scratch:rw",
+			"-v", "/host/out:/seed/outputs",
+			"-e", "ALLOCATED_CPUS=1.5", "-e", "ALLOCATED_DISK=10", "-e", "ALLOCATED_MEM=512.2", "-e", "ALLOCATED_SHAREDMEM=0",
+			"-e", "DB_HOST=db.example.com",
+			"-e", "INPUT_FILE=/seed/inputs/INPUT_FILE/in.h5",
+			"-e", "MOUNT_PATH=/the/container/path",
+			"-e", "OUTPUT_DIR=/seed/outputs",
+			"-e", "SCRATCH=/scratch",
+			"my-job-0.1.0-seed:0.1.0", "/seed/inputs/INPUT_FILE/in.h5", "/seed/outputs"}, ""},
+		{RunRequest{
+			Inputs:    map[string][]string{"INPUT_FILE": {"/a/in.h5", "/b/in.h5"}, "OTHER": {"/x"}},
+			Settings:  map[string]string{"DB_PORT": "5432"},
+			Mounts:    map[string]string{"MOUNT_PATH": "/host/mount"},
+			Json:      map[string]interface{}{"count": "1"},
+			Resources: resources,
+		}, nil, "ERROR: Invalid run request:\n" +
+			"INPUT_FILE: 2 files provided for a single file input\n" +
+			"INPUT_FILE: more than one file named in.h5\n" +
+			"OTHER: not declared as an input file\n" +
+			"count: not declared as a json input\n" +
+			"SCRATCH: no host path provided for mount\n" +
+			"DB_PORT: not declared as a setting\n" +
+			"OUTPUT_DIR: no output directory provided"},
+		{RunRequest{OutputDir: "/out", Mounts: map[string]string{"MOUNT_PATH": "/m", "SCRATCH": "/s"}},
+			nil, "INPUT_FILE: required input not provided"},
+		{RunRequest{
+			Inputs:    map[string][]string{"INPUT_FILE": {"/data/run:1/in.h5"}},
+			Mounts:    map[string]string{"MOUNT_PATH": "/host/a:b", "SCRATCH": "/s"},
+			OutputDir: "/out:rw",
+			Resources: resources,
+		}, nil, "ERROR: Invalid run request:\n" +
+			"INPUT_FILE: path /data/run:1/in.h5 cannot be mounted because it contains a colon\n" +
+			"MOUNT_PATH: path /host/a:b cannot be mounted because it contains a colon\n" +
+			"OUTPUT_DIR: path /out:rw cannot be mounted because it contains a colon"},
+	}
+
+	for _, c := range cases {
+		args, err := DockerRunArgs(&seed, c.req)
+		if c.args != nil && !reflect.DeepEqual(args, c.args) {
+			t.Errorf("DockerRunArgs(%v) returned\n%v\n expected\n%v", c.req, args, c.args)
+		}
+		if err == nil && c.errStr != "" {
+			t.Errorf("DockerRunArgs(%v) did not return an error when one was expected: %v", c.req, c.errStr)
+		}
+		if err != nil && (c.errStr == "" || !strings.Contains(err.Error(), c.errStr)) {
+			t.Errorf("DockerRunArgs(%v) returned an error: %v\n expected %v", c.req, err, c.errStr)
+		}
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	seed, _ := SeedFromManifestString(`{"seedVersion": "1.0.0", "job": {"name": "echo", "jobVersion": "1.0.0",
+		"packageVersion": "1.0.0", "interface": {"command": "say '${MESSAGE}' ${OUTPUT_DIR}",
+		"settings": [{"name": "MESSAGE"}]}}}`)
+
+	command, err := DryRun(&seed, RunRequest{Image: "echo:1", OutputDir: "/out", Settings: map[string]string{"MESSAGE": "it's here"}})
+	expected := `docker run -v /out:/seed/outputs -e 'MESSAGE=it'\''s here' -e OUTPUT_DIR=/seed/outputs echo:1 say '${MESSAGE}' /seed/outputs`
+	if err != nil || command != expected {
+		t.Errorf("DryRun returned %v, %v\n expected %v", command, err, expected)
+	}
+}
+
+func TestDryRunSecrets(t *testing.T) {
+	seed, _ := SeedFromManifestString(`{"seedVersion": "1.0.0", "job": {"name": "login", "jobVersion": "1.0.0",
+		"packageVersion": "1.0.0", "interface": {"command": "login ${DB_PASS}",
+		"settings": [{"name": "DB_PASS", "secret": true}, {"name": "DB_USER"}]}}}`)
+
+	req := RunRequest{Image: "login:1", OutputDir: "/out", Settings: map[string]string{"DB_USER": "admin", "DB_PASS": "dry-run-pass"}}
+	command, err := DryRun(&seed, req)
+	expected := `docker run -v /out:/seed/outputs -e DB_PASS -e DB_USER=admin -e OUTPUT_DIR=/seed/outputs login:1 login '${DB_PASS}'`
+	if err != nil || command != expected {
+		t.Errorf("DryRun returned %v, %v\n expected %v", command, err, expected)
+	}
+	if util.Redact("dry-run-pass") != "dry-run-pass" {
+		t.Errorf("DryRun did not unregister the secret settings")
+	}
+}
+
+func TestDockerRunArgsSecrets(t *testing.T) {
+	seed, _ := SeedFromManifestString(`{"seedVersion": "1.0.0", "job": {"name": "login", "jobVersion": "1.0.0",
+		"packageVersion": "1.0.0", "interface": {"command": "login --password=${DB_PASS} \"$DB_PASS\" $DB_USER",
+		"settings": [{"name": "DB_PASS", "secret": true}, {"name": "DB_USER"}]}}}`)
+
+	req := RunRequest{Image: "login:1", OutputDir: "/out", Settings: map[string]string{"DB_USER": "admin", "DB_PASS": "args-pass"}}
+	args, err := DockerRunArgs(&seed, req)
+	if err != nil {
+		t.Fatalf("DockerRunArgs returned an error: %v", err)
+	}
+	for _, arg := range args {
+		if strings.Contains(arg, "args-pass") {
+			t.Errorf("DockerRunArgs passed the secret as an argument: %v", args)
+		}
+	}
+	expected := []string{"login:1", "login", "--password=${DB_PASS}", "${DB_PASS}", "admin"}
+	if command := args[len(args)-len(expected):]; !reflect.DeepEqual(command, expected) {
+		t.Errorf("DockerRunArgs expanded the command to %v, expected %v", command, expected)
+	}
+}
@@ -0,0 +1,258 @@
+package objects
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ngageoint/seed-common/util"
+)
+
+//dockerExecutable is the docker client used to run jobs
+var dockerExecutable = "docker"
+
+//StopGracePeriod is how long a timed out container is given to exit before it is killed
+var StopGracePeriod = 10 * time.Second
+
+//RunResult describes a single run of a seed job
+type RunResult struct {
+	Container string
+	//Command is the docker run command line that was (or for a dry run, would have been) executed
+	Command  string
+	DryRun   bool
+	Started  time.Time
+	Duration time.Duration
+	Exit     ExitClassification
+	Outputs  OutputResult
+	//MediaTypes lists the inputs and outputs that do not match their declared media types
+	MediaTypes []MediaTypeMismatch
+}
+
+//Success checks if the job exited successfully and produced valid outputs
+func (r *RunResult) Success() bool {
+	return !r.DryRun && r.Exit.Success() && r.Outputs.Valid()
+}
+
+//RunJob runs a seed job end to end: the inputs are checked and the output directory created, the
+// container is launched with DockerRunArgs and its logs streamed to util.StdOut and util.StdErr.
+// A container that runs longer than Job.Timeout seconds is stopped. The exit code is classified
+// with ClassifyExit and the outputs are collected with CollectOutputs. Input and output media
+// types are verified according to req.MediaType. Secret settings are resolved through req.Secrets
+// and passed to docker through its environment; DockerRunArgs keeps their values out of the
+// arguments, including the expanded command.
+// An error is only returned if the job could not be run; job failures are reported in the result.
+// If dryRun is set the docker command is printed and returned without running it.
+func RunJob(seed *Seed, req RunRequest, dryRun bool) (RunResult, error) {
+	result := RunResult{Container: req.Name, DryRun: dryRun}
+	if result.Container == "" {
+		result.Container = fmt.Sprintf("%s-%d", containerPrefix(seed.Job.Name), time.Now().UnixNano())
+	}
+	remove := req.Remove
+	req.Name, req.Remove = result.Container, false
+
+	if dryRun {
+		command, err := DryRun(seed, req)
+		result.Command = command
+		return result, err
+	}
+
+	if err := stageRun(req); err != nil {
+		return result, err
+	}
+	// resolve secrets once; DockerRunArgs passes them by name and they are set in the client's environment
+	settings, violations := resolveSettings(seed, req)
+	if len(violations) > 0 {
+		return result, &RunRequestError{Violations: violations}
+	}
+	req.Settings, req.Secrets = settings, nil
+	defer registerSecretSettings(seed, settings)()
+
+	if err := result.verifyInputs(seed, req); err != nil {
+		return result, err
+	}
+
+	args, err := DockerRunArgs(seed, req)
+	if err != nil {
+		return result, err
+	}
+	result.Command = util.Redact(FormatCommand(dockerExecutable, args))
+
+	cmd := exec.Command(dockerExecutable, args...)
+	cmd.Env = append(os.Environ(), secretEnvironment(seed, settings)...)
+	cmd.Stdout = writerOrDiscard(util.StdOut)
+	cmd.Stderr = writerOrDiscard(util.StdErr)
+
+	util.PrintUtil("INFO: Running %s\n", result.Container)
+	result.Started = time.Now()
+	if err = cmd.Start(); err != nil {
+		msg := fmt.Sprintf("ERROR: Error executing docker run. %s", err.Error())
+		return result, errors.New(msg)
+	}
+
+	var timedOut int32
+	if seed.Job.Timeout > 0 {
+		timer := time.AfterFunc(time.Duration(seed.Job.Timeout)*time.Second, func() {
+			atomic.StoreInt32(&timedOut, 1)
+			util.PrintUtil("WARN: %s exceeded its timeout of %d seconds; stopping\n", result.Container, seed.Job.Timeout)
+			stopContainer(result.Container)
+		})
+		defer timer.Stop()
+	}
+
+	waitErr := cmd.Wait()
+	result.Duration = time.Since(result.Started)
+
+	status, inspectErr := inspectExit(result.Container)
+	if inspectErr != nil {
+		// the container may never have been created, i.e. docker run failed
+		status = ExitStatus{Code: exitCode(waitErr)}
+	}
+	status.TimedOut = atomic.LoadInt32(&timedOut) == 1
+	result.Exit = ClassifyExit(seed, status)
+
+	if remove && inspectErr == nil {
+		removeContainer(result.Container)
+	}
+
+	result.Outputs = CollectOutputs(seed, req.OutputDir)
+	mismatches, err := VerifyOutputMediaTypes(seed, result.Outputs.Files, req.MediaType)
+	if err != nil {
+		return result, err
+	}
+	for _, m := range mismatches {
+		if m.Severity == SeverityError {
+			result.Outputs.violation(m.Name, "%s", m.message())
+		} else {
+			util.PrintUtil("WARN: Output %s\n", m.String())
+		}
+	}
+	result.MediaTypes = append(result.MediaTypes, mismatches...)
+
+	return result, nil
+}
+
+//containerPrefix returns the job name with the characters docker does not allow in container names
+// removed, falling back to "seed" if nothing valid remains. Names must start with a letter or digit.
+func containerPrefix(name string) string {
+	prefix := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '.' || r == '-' {
+			return r
+		}
+		return -1
+	}, name)
+	prefix = strings.TrimLeft(prefix, "_.-")
+	if prefix == "" {
+		return "seed"
+	}
+	return prefix
+}
+
+//verifyInputs checks the media types of the input files, rejecting the request if they are enforced
+func (r *RunResult) verifyInputs(seed *Seed, req RunRequest) error {
+	mismatches, err := VerifyInputMediaTypes(seed, req.Inputs, req.MediaType)
+	if err != nil {
+		return err
+	}
+	r.MediaTypes = mismatches
+
+	violations := []InputViolation{}
+	for _, m := range mismatches {
+		if m.Severity == SeverityError {
+			violations = append(violations, InputViolation{m.Name, m.message()})
+		} else {
+			util.PrintUtil("WARN: Input %s\n", m.String())
+		}
+	}
+	if len(violations) > 0 {
+		return &RunRequestError{Violations: violations}
+	}
+	return nil
+}
+
+//stageRun checks the input files exist and prepares an empty output directory
+func stageRun(req RunRequest) error {
+	violations := []InputViolation{}
+	for _, name := range sortedListKeys(req.Inputs) {
+		for _, file := range req.Inputs[name] {
+			if info, err := os.Stat(file); err != nil {
+				violations = append(violations, InputViolation{name, fmt.Sprintf("input file %s not found", file)})
+			} else if info.IsDir() {
+				violations = append(violations, InputViolation{name, fmt.Sprintf("input file %s is a directory", file)})
+			}
+		}
+	}
+
+	if req.OutputDir != "" {
+		if err := os.MkdirAll(req.OutputDir, os.ModePerm); err != nil {
+			violations = append(violations, InputViolation{OutputDirVariable, err.Error()})
+		} else if files, err := ioutil.ReadDir(req.OutputDir); err != nil {
+			violations = append(violations, InputViolation{OutputDirVariable, err.Error()})
+		} else if len(files) > 0 {
+			violations = append(violations, InputViolation{OutputDirVariable,
+				fmt.Sprintf("output directory %s is not empty", req.OutputDir)})
+		}
+	}
+
+	if len(violations) > 0 {
+		return &RunRequestError{Violations: violations}
+	}
+	return nil
+}
+
+//inspectExit returns the exit code and out of memory status of a finished container
+func inspectExit(container string) (ExitStatus, error) {
+	out, err := exec.Command(dockerExecutable, "inspect", "-f",
+		"{{.State.ExitCode}} {{.State.OOMKilled}}", container).Output()
+	if err != nil {
+		return ExitStatus{}, err
+	}
+
+	fields := strings.Fields(string(out))
+	if len(fields) != 2 {
+		msg := fmt.Sprintf("ERROR: Unexpected docker inspect output %q", string(out))
+		return ExitStatus{}, errors.New(msg)
+	}
+	code, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return ExitStatus{}, err
+	}
+	return ExitStatus{Code: code, OOMKilled: fields[1] == "true"}, nil
+}
+
+func stopContainer(container string) {
+	seconds := strconv.Itoa(int(StopGracePeriod / time.Second))
+	if out, err := exec.Command(dockerExecutable, "stop", "-t", seconds, container).CombinedOutput(); err != nil {
+		util.PrintUtil("ERROR: Error stopping %s. %s\n%s\n", container, err.Error(), string(out))
+	}
+}
+
+func removeContainer(container string) {
+	if out, err := exec.Command(dockerExecutable, "rm", "-f", container).CombinedOutput(); err != nil {
+		util.PrintUtil("ERROR: Error removing %s. %s\n%s\n", container, err.Error(), string(out))
+	}
+}
+
+//exitCode returns the exit code of a finished command, or 125 (docker run failed) if it is unknown
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() >= 0 {
+		return exitErr.ExitCode()
+	}
+	return 125
+}
+
+func writerOrDiscard(w io.Writer) io.Writer {
+	if w == nil {
+		return ioutil.Discard
+	}
+	return w
+}
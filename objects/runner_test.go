@@ -0,0 +1,265 @@
+package objects
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ngageoint/seed-common/util"
+)
+
+//fakeDocker emulates the docker run, inspect, stop and rm commands used by RunJob. The container
+// exit code, OOM status, run time and outputs are controlled by FAKE_* environment variables.
+const fakeDocker = `#!/bin/sh
+state="$FAKE_DOCKER_STATE"
+cmd=$1
+shift
+case $cmd in
+run)
+  args="$*"
+  while [ $# -gt 0 ]; do
+    case $1 in
+      --name) name=$2; shift;;
+      -v) case $2 in *:/seed/outputs) out=${2%:/seed/outputs};; esac; shift;;
+      -e|--cpus|--memory|--shm-size) shift;;
+    esac
+    shift
+  done
+  echo $$ > "$state/$name.pid"
+  echo "$args" > "$state/$name.args"
+  env > "$state/$name.env"
+  echo "running $name"
+  echo "progress" >&2
+  if [ -n "$FAKE_SLEEP" ]; then
+    sleep "$FAKE_SLEEP" >/dev/null 2>&1 &
+    echo $! > "$state/$name.sleep"
+    wait $!
+  fi
+  if [ -n "$FAKE_OUTPUTS" ]; then
+    touch "$out/outfile1.tif"
+    echo '{"cellCount": 3}' > "$out/seed.outputs.json"
+  fi
+  echo "${FAKE_EXIT:-0} ${FAKE_OOM:-false}" > "$state/$name"
+  exit ${FAKE_EXIT:-0}
+  ;;
+inspect)
+  for last in "$@"; do :; done
+  cat "$state/$last" 2>/dev/null || { echo "No such container" >&2; exit 1; }
+  ;;
+stop)
+  for last in "$@"; do :; done
+  echo "143 false" > "$state/$last"
+  kill $(cat "$state/$last.pid") $(cat "$state/$last.sleep" 2>/dev/null) 2>/dev/null
+  ;;
+rm)
+  for last in "$@"; do :; done
+  rm -f "$state/$last"
+  echo "$last" >> "$state/removed"
+  ;;
+esac
+`
+
+//fakeDockerVariables are the environment variables controlling fakeDocker
+var fakeDockerVariables = []string{"FAKE_DOCKER_STATE", "FAKE_OUTPUTS", "FAKE_EXIT", "FAKE_OOM", "FAKE_SLEEP"}
+
+//unsetFakeDockerVariables removes the fakeDocker variables so they do not leak into other tests
+func unsetFakeDockerVariables() {
+	for _, name := range fakeDockerVariables {
+		os.Unsetenv(name)
+	}
+}
+
+func TestRunJob(t *testing.T) {
+	dir, err := ioutil.TempDir("", "runjob")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	docker := filepath.Join(dir, "docker")
+	ioutil.WriteFile(docker, []byte(fakeDocker), 0755)
+	input := filepath.Join(dir, "in.h5")
+	ioutil.WriteFile(input, []byte("hdf5"), 0644)
+	os.Setenv("FAKE_DOCKER_STATE", dir)
+	defer unsetFakeDockerVariables()
+
+	oldDocker, oldStdOut := dockerExecutable, util.StdOut
+	defer func() { dockerExecutable, util.StdOut = oldDocker, oldStdOut }()
+	dockerExecutable = docker
+	var stdout bytes.Buffer
+	util.StdOut = &stdout
+
+	seed, err := LoadSeedFromManifestFile("../testdata/complete/seed.manifest.json")
+	if err != nil {
+		t.Fatalf("Error loading manifest: %v", err)
+	}
+	seed.Job.Timeout = 1
+
+	cases := []struct {
+		env      map[string]string
+		remove   bool
+		success  bool
+		code     int
+		category string
+		oom      bool
+		timedOut bool
+	}{
+		{map[string]string{"FAKE_OUTPUTS": "1"}, true, true, 0, "", false, false},
+		{map[string]string{"FAKE_EXIT": "1"}, false, false, 1, "data", false, false},
+		{map[string]string{"FAKE_EXIT": "137", "FAKE_OOM": "true"}, false, false, 137, "system", true, false},
+		{map[string]string{"FAKE_SLEEP": "10"}, true, false, 143, "system", false, true},
+	}
+
+	for i, c := range cases {
+		for _, name := range []string{"FAKE_OUTPUTS", "FAKE_EXIT", "FAKE_OOM", "FAKE_SLEEP"} {
+			os.Setenv(name, c.env[name])
+		}
+		stdout.Reset()
+		req := RunRequest{
+			Name:      "case-" + string(rune('0'+i)),
+			Inputs:    map[string][]string{"INPUT_FILE": {input}},
+			Mounts:    map[string]string{"MOUNT_PATH": dir},
+			OutputDir: filepath.Join(dir, "out", string(rune('0'+i))),
+			Remove:    c.remove,
+		}
+
+		result, err := RunJob(&seed, req, false)
+		if err != nil {
+			t.Errorf("case %d: RunJob returned an error: %v", i, err)
+			continue
+		}
+		status := result.Exit.Status
+		if result.Success() != c.success || status.Code != c.code || status.OOMKilled != c.oom || status.TimedOut != c.timedOut {
+			t.Errorf("case %d: RunJob returned success %v, status %+v, expected %v, %v", i, result.Success(), status, c.success, c.code)
+		}
+		if c.category != "" && result.Exit.Error.Category != c.category {
+			t.Errorf("case %d: RunJob classified the exit as %v, expected %v", i, result.Exit.Error, c.category)
+		}
+		if result.Outputs.Valid() != c.success {
+			t.Errorf("case %d: RunJob returned output violations %v", i, result.Outputs.Violations)
+		}
+		if !strings.Contains(stdout.String(), "running "+req.Name) {
+			t.Errorf("case %d: RunJob did not stream the container output: %q", i, stdout.String())
+		}
+		if !strings.HasPrefix(result.Command, docker+" run --name "+req.Name) {
+			t.Errorf("case %d: RunJob ran %v", i, result.Command)
+		}
+		removed, _ := ioutil.ReadFile(filepath.Join(dir, "removed"))
+		if strings.Contains(string(removed), req.Name) != c.remove {
+			t.Errorf("case %d: RunJob removed containers %q, expected removal %v", i, removed, c.remove)
+		}
+	}
+}
+
+func TestRunJobErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "runjob")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, "existing.txt"), []byte("x"), 0644)
+
+	seed, _ := LoadSeedFromManifestFile("../testdata/complete/seed.manifest.json")
+
+	req := RunRequest{
+		Inputs:    map[string][]string{"INPUT_FILE": {filepath.Join(dir, "missing.h5")}},
+		Mounts:    map[string]string{"MOUNT_PATH": dir},
+		OutputDir: dir,
+	}
+	_, err = RunJob(&seed, req, false)
+	if _, ok := err.(*RunRequestError); !ok || !strings.Contains(err.Error(), "missing.h5 not found") ||
+		!strings.Contains(err.Error(), "is not empty") {
+		t.Errorf("RunJob returned %v, expected a RunRequestError", err)
+	}
+
+	req.Inputs["INPUT_FILE"] = []string{filepath.Join(dir, "existing.txt")}
+	req.OutputDir = filepath.Join(dir, "out")
+	req.MediaType = MediaTypeEnforce
+	_, err = RunJob(&seed, req, false)
+	if err == nil || !strings.Contains(err.Error(), "existing.txt is text/plain, expected image/x-hdf5-image") {
+		t.Errorf("RunJob returned %v, expected a media type error", err)
+	}
+
+	result, err := RunJob(&seed, req, true)
+	if err != nil || !result.DryRun || result.Success() || !strings.HasPrefix(result.Command, "docker run --name my-job-") {
+		t.Errorf("RunJob dry run returned %+v, %v", result, err)
+	}
+}
+
+func TestRunJobSecrets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "runjob")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	docker := filepath.Join(dir, "docker")
+	ioutil.WriteFile(docker, []byte(fakeDocker), 0755)
+	input := filepath.Join(dir, "in.h5")
+	ioutil.WriteFile(input, []byte("hdf5"), 0644)
+	unsetFakeDockerVariables()
+	os.Setenv("FAKE_DOCKER_STATE", dir)
+	defer unsetFakeDockerVariables()
+	os.Setenv("RUNJOB_SECRET_DB_HOST", "s3cret-host")
+	defer os.Unsetenv("RUNJOB_SECRET_DB_HOST")
+
+	oldDocker := dockerExecutable
+	defer func() { dockerExecutable = oldDocker }()
+	dockerExecutable = docker
+
+	seed, _ := LoadSeedFromManifestFile("../testdata/complete/seed.manifest.json")
+	seed.Job.Interface.Settings[0].Secret = true
+	seed.Job.Interface.Command = "${INPUT_FILE} ${OUTPUT_DIR} --host ${DB_HOST}"
+
+	req := RunRequest{
+		Name:      "secret-run",
+		Inputs:    map[string][]string{"INPUT_FILE": {input}},
+		Mounts:    map[string]string{"MOUNT_PATH": dir},
+		OutputDir: filepath.Join(dir, "out"),
+		Secrets:   &util.EnvSecretProvider{Prefix: "RUNJOB_SECRET_"},
+	}
+	result, err := RunJob(&seed, req, false)
+	if err != nil {
+		t.Fatalf("RunJob returned an error: %v", err)
+	}
+	if strings.Contains(result.Command, "s3cret-host") || !strings.Contains(result.Command, " -e DB_HOST ") {
+		t.Errorf("RunJob passed the secret as an argument: %v", result.Command)
+	}
+	args, _ := ioutil.ReadFile(filepath.Join(dir, "secret-run.args"))
+	if !strings.Contains(string(args), "--host ${DB_HOST}") || strings.Contains(string(args), "s3cret-host") {
+		t.Errorf("RunJob passed the secret to docker as an argument: %s", args)
+	}
+	env, _ := ioutil.ReadFile(filepath.Join(dir, "secret-run.env"))
+	if !strings.Contains(string(env), "DB_HOST=s3cret-host") {
+		t.Errorf("RunJob did not pass the secret in the environment of docker")
+	}
+
+	req.Name, req.OutputDir = "missing-secret", filepath.Join(dir, "out2")
+	req.Secrets = &util.EnvSecretProvider{Prefix: "RUNJOB_MISSING_"}
+	_, err = RunJob(&seed, req, false)
+	if err == nil || !strings.Contains(err.Error(), "DB_HOST: secret not found") {
+		t.Errorf("RunJob returned %v, expected a missing secret error", err)
+	}
+}
+
+func TestContainerPrefix(t *testing.T) {
+	cases := []struct {
+		name   string
+		prefix string
+	}{
+		{"my-job", "my-job"},
+		{"", "seed"},
+		{"my job/v1:2", "myjobv12"},
+		{"-._job", "job"},
+		{"?!", "seed"},
+	}
+
+	for _, c := range cases {
+		if prefix := containerPrefix(c.name); prefix != c.prefix {
+			t.Errorf("containerPrefix(%q) returned %q, expected %q", c.name, prefix, c.prefix)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package objects
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ngageoint/seed-common/constants"
+	"github.com/ngageoint/seed-common/util"
+)
+
+const (
+	//DefaultBaseImage is the image generated Dockerfiles are built FROM when none is given
+	DefaultBaseImage = "alpine"
+
+	//DefaultJobTimeout is the timeout in seconds given to generated jobs when none is given
+	DefaultJobTimeout = 3600
+
+	scaffoldDockerfile = "Dockerfile"
+	scaffoldEntrypoint = "entrypoint.sh"
+	scaffoldAppDir     = "/app"
+)
+
+//ScaffoldOptions describes a new seed job to generate. Name and JobVersion are required; the
+// remaining fields are optional and the interface members and resources are used as given. No
+// resources are declared unless given.
+type ScaffoldOptions struct {
+	Name           string
+	JobVersion     string
+	PackageVersion string
+	Title          string
+	Description    string
+	Maintainer     Maintainer
+	Timeout        int
+	BaseImage      string
+	Inputs         Inputs
+	Outputs        Outputs
+	Mounts         []Mount
+	Settings       []Setting
+	Resources      Resources
+}
+
+//ScaffoldResult lists the seed and the files written by ScaffoldJob
+type ScaffoldResult struct {
+	Seed       Seed
+	Manifest   string
+	Dockerfile string
+	Entrypoint string
+}
+
+//ScaffoldSeed returns the seed manifest ScaffoldJob would generate for the given options. The
+// manifest is checked against the seed schema and Lint; any schema violation or lint error is
+// returned as an error.
+func ScaffoldSeed(opts ScaffoldOptions) (Seed, error) {
+	if opts.Name == "" || opts.JobVersion == "" {
+		return Seed{}, errors.New("ERROR: A job name and job version are required to generate a seed job")
+	}
+
+	job := Job{
+		Name:           opts.Name,
+		JobVersion:     opts.JobVersion,
+		PackageVersion: opts.PackageVersion,
+		Title:          opts.Title,
+		Description:    opts.Description,
+		Maintainer:     opts.Maintainer,
+		Timeout:        opts.Timeout,
+		Interface: Interface{
+			Inputs:   opts.Inputs,
+			Outputs:  opts.Outputs,
+			Mounts:   opts.Mounts,
+			Settings: opts.Settings,
+		},
+		Resources: opts.Resources,
+	}
+	if job.PackageVersion == "" {
+		job.PackageVersion = "1.0.0"
+	}
+	if job.Title == "" {
+		job.Title = opts.Name
+	}
+	if job.Description == "" {
+		job.Description = "Seed job " + opts.Name
+	}
+	if job.Timeout == 0 {
+		job.Timeout = DefaultJobTimeout
+	}
+
+	// pass every input file and the output directory on the command line; everything else is
+	// available to the entrypoint as environment variables
+	args := []string{}
+	for _, f := range job.Interface.Inputs.Files {
+		args = append(args, "${"+util.GetNormalizedVariable(f.Name)+"}")
+	}
+	args = append(args, "${"+OutputDirVariable+"}")
+	job.Interface.Command = strings.Join(args, " ")
+
+	seed := Seed{SeedVersion: CurrentSeedVersion, Job: job}.Normalized()
+	if seed.Job.Resources.Scalar == nil {
+		// the schema requires the scalar array even when no resources are declared
+		seed.Job.Resources.Scalar = []Scalar{}
+	}
+	if err := checkScaffoldSeed(&seed); err != nil {
+		return Seed{}, err
+	}
+
+	return seed, nil
+}
+
+//checkScaffoldSeed validates the seed against the manifest schema and fails on any lint error
+func checkScaffoldSeed(seed *Seed) error {
+	if problems := validateSeed(seed); len(problems) > 0 {
+		msg := fmt.Sprintf("ERROR: Generated seed manifest is invalid:\n%s", strings.Join(problems, "\n"))
+		return errors.New(msg)
+	}
+	return nil
+}
+
+//ScaffoldJob generates a new seed job in the given directory: a seed.manifest.json, a Dockerfile
+// with the com.ngageoint.seed.manifest LABEL encoded by LoadManifestLabel and an entrypoint script
+// stub that reads the declared environment variables. Existing files are never overwritten.
+func ScaffoldJob(directory string, opts ScaffoldOptions) (ScaffoldResult, error) {
+	seed, err := ScaffoldSeed(opts)
+	if err != nil {
+		return ScaffoldResult{}, err
+	}
+
+	result := ScaffoldResult{
+		Seed:       seed,
+		Manifest:   filepath.Join(directory, constants.SeedFileName),
+		Dockerfile: filepath.Join(directory, scaffoldDockerfile),
+		Entrypoint: filepath.Join(directory, scaffoldEntrypoint),
+	}
+	for _, file := range []string{result.Manifest, result.Dockerfile, result.Entrypoint} {
+		if _, err := os.Stat(file); err == nil {
+			msg := fmt.Sprintf("ERROR: %s already exists", file)
+			return ScaffoldResult{}, errors.New(msg)
+		}
+	}
+
+	if err = os.MkdirAll(directory, os.ModePerm); err != nil {
+		return ScaffoldResult{}, err
+	}
+
+	manifest, err := json.MarshalIndent(seed, "", "  ")
+	if err != nil {
+		return ScaffoldResult{}, err
+	}
+	if err = ioutil.WriteFile(result.Manifest, append(manifest, '\n'), 0644); err != nil {
+		return ScaffoldResult{}, err
+	}
+
+	label, err := LoadManifestLabel(result.Manifest)
+	if err != nil {
+		return ScaffoldResult{}, err
+	}
+	baseImage := opts.BaseImage
+	if baseImage == "" {
+		baseImage = DefaultBaseImage
+	}
+	if err = ioutil.WriteFile(result.Dockerfile, []byte(scaffoldDockerfileContents(baseImage, label)), 0644); err != nil {
+		return ScaffoldResult{}, err
+	}
+
+	if err = ioutil.WriteFile(result.Entrypoint, []byte(scaffoldEntrypointContents(&seed)), 0755); err != nil {
+		return ScaffoldResult{}, err
+	}
+
+	return result, nil
+}
+
+func scaffoldDockerfileContents(baseImage, label string) string {
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "FROM %s\n\n", baseImage)
+	fmt.Fprintf(&buffer, "COPY %s %s/%s\n", scaffoldEntrypoint, scaffoldAppDir, scaffoldEntrypoint)
+	fmt.Fprintf(&buffer, "RUN chmod +x %s/%s\n\n", scaffoldAppDir, scaffoldEntrypoint)
+	fmt.Fprintf(&buffer, "ENTRYPOINT [\"%s/%s\"]\n\n", scaffoldAppDir, scaffoldEntrypoint)
+	fmt.Fprintf(&buffer, "LABEL %s=%s\n", constants.SeedManifestLabel, label)
+	return buffer.String()
+}
+
+func scaffoldEntrypointContents(seed *Seed) string {
+	iface := seed.Job.Interface
+
+	var buffer bytes.Buffer
+	buffer.WriteString("#!/bin/sh\n")
+	fmt.Fprintf(&buffer, "# Entrypoint for the %s seed job.\n", seed.Job.Name)
+	fmt.Fprintf(&buffer, "# Seed runs this script with the arguments: %s\n", iface.Command)
+	buffer.WriteString("set -e\n")
+
+	required := []string{}
+	section := func(title string, names []string) {
+		if len(names) == 0 {
+			return
+		}
+		fmt.Fprintf(&buffer, "\n# %s\n", title)
+		for _, name := range names {
+			fmt.Fprintf(&buffer, "echo \"%s=${%s}\"\n", name, name)
+		}
+	}
+
+	inputs := []string{}
+	for _, f := range iface.Inputs.Files {
+		inputs = append(inputs, util.GetNormalizedVariable(f.Name))
+		if f.Required {
+			required = append(required, util.GetNormalizedVariable(f.Name))
+		}
+	}
+	for _, j := range iface.Inputs.Json {
+		inputs = append(inputs, util.GetNormalizedVariable(j.Name))
+		if j.Required {
+			required = append(required, util.GetNormalizedVariable(j.Name))
+		}
+	}
+	section("inputs", inputs)
+
+	mounts := []string{}
+	for _, m := range iface.Mounts {
+		mounts = append(mounts, util.GetNormalizedVariable(m.Name))
+	}
+	section("mounts", mounts)
+
+	settings, secrets := []string{}, []string{}
+	for _, s := range iface.Settings {
+		if s.Secret {
+			secrets = append(secrets, util.GetNormalizedVariable(s.Name))
+		} else {
+			settings = append(settings, util.GetNormalizedVariable(s.Name))
+		}
+	}
+	section("settings", settings)
+	if len(secrets) > 0 {
+		fmt.Fprintf(&buffer, "# secret settings are not printed: %s\n", strings.Join(secrets, " "))
+	}
+
+	section("resources", AllocatedVariables(seed))
+
+	if len(required) > 0 {
+		buffer.WriteString("\n# required inputs\n")
+		for _, name := range required {
+			fmt.Fprintf(&buffer, "if [ -z \"${%s}\" ]; then\n  echo \"%s is required\" >&2\n  exit 1\nfi\n", name, name)
+		}
+	}
+
+	fmt.Fprintf(&buffer, "\nmkdir -p \"${%s}\"\n", OutputDirVariable)
+	buffer.WriteString("\n# TODO: process the inputs and write the outputs to ${OUTPUT_DIR}\n")
+	for _, f := range iface.Outputs.Files {
+		fmt.Fprintf(&buffer, "#  %s: %s\n", f.Name, f.Pattern)
+	}
+	if len(iface.Outputs.JSON) > 0 {
+		fmt.Fprintf(&buffer, "#  json outputs are read from ${%s}/%s:\n", OutputDirVariable, constants.SeedOutputsFileName)
+		for _, j := range iface.Outputs.JSON {
+			key := j.Key
+			if key == "" {
+				key = j.Name
+			}
+			fmt.Fprintf(&buffer, "#   %s (%s)\n", key, j.Type)
+		}
+	}
+
+	return buffer.String()
+}
@@ -0,0 +1,115 @@
+package objects
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ngageoint/seed-common/constants"
+	"github.com/ngageoint/seed-common/util"
+)
+
+func TestScaffoldJob(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scaffold")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	opts := ScaffoldOptions{
+		Name:       "new-job",
+		JobVersion: "0.1.0",
+		Maintainer: Maintainer{Name: "Jane Doe", Email: "jdoe@example.com"},
+		Inputs: Inputs{
+			Files: []InFile{{Name: "INPUT_FILE", MediaTypes: []string{"image/tiff"}, Required: true}},
+			Json:  []InJson{{Name: "threshold", Type: "number"}},
+		},
+		Outputs: Outputs{
+			Files: []OutFile{{Name: "OUTPUT_TIFF", Pattern: "*.tif", Required: true}},
+			JSON:  []OutJson{{Name: "cell_count", Key: "cellCount", Type: "integer"}},
+		},
+		Mounts:   []Mount{{Name: "REF_DATA", Path: "/ref"}},
+		Settings: []Setting{{Name: "DB_HOST"}, {Name: "DB_PASS", Secret: true}},
+	}
+
+	result, err := ScaffoldJob(filepath.Join(dir, "new-job"), opts)
+	if err != nil {
+		t.Fatalf("ScaffoldJob returned an error: %v", err)
+	}
+
+	manifest, _ := ioutil.ReadFile(result.Manifest)
+	if schemaErrors, err := util.Validate(manifest, constants.SchemaManifest); err != nil || len(schemaErrors) != 0 {
+		t.Errorf("Generated manifest failed validation: %v, %v", schemaErrors, err)
+	}
+	seed, err := LoadSeedFromManifestFile(result.Manifest)
+	if err != nil {
+		t.Fatalf("Error loading generated manifest: %v", err)
+	}
+	for _, f := range Lint(&seed) {
+		if f.Severity == SeverityError {
+			t.Errorf("Generated manifest has lint error: %v", f)
+		}
+	}
+	if seed.Job.Interface.Command != "${INPUT_FILE} ${OUTPUT_DIR}" || seed.Job.Interface.Mounts[0].Mode != "ro" ||
+		seed.Job.Timeout != DefaultJobTimeout || seed.Job.PackageVersion != "1.0.0" || len(seed.Job.Resources.Scalar) != 0 {
+		t.Errorf("Generated manifest has unexpected values: %v", seed.Job)
+	}
+
+	dockerfile, _ := ioutil.ReadFile(result.Dockerfile)
+	label := ""
+	for _, line := range strings.Split(string(dockerfile), "\n") {
+		if strings.HasPrefix(line, "LABEL com.ngageoint.seed.manifest=") {
+			label = strings.TrimPrefix(line, "LABEL com.ngageoint.seed.manifest=")
+		}
+	}
+	expected, _ := LoadManifestLabel(result.Manifest)
+	if label != expected {
+		t.Errorf("Generated Dockerfile LABEL %v, expected %v", label, expected)
+	}
+	if !strings.Contains(string(dockerfile), `ENTRYPOINT ["/app/entrypoint.sh"]`) {
+		t.Errorf("Generated Dockerfile does not run the entrypoint:\n%s", dockerfile)
+	}
+
+	entrypoint, _ := ioutil.ReadFile(result.Entrypoint)
+	for _, expected := range []string{"${INPUT_FILE}", "${THRESHOLD}", "${REF_DATA}", "${DB_HOST}", "${ALLOCATED_CPUS}", "cellCount"} {
+		if !strings.Contains(string(entrypoint), expected) {
+			t.Errorf("Generated entrypoint does not contain %v:\n%s", expected, entrypoint)
+		}
+	}
+	if strings.Contains(string(entrypoint), "${DB_PASS}") {
+		t.Errorf("Generated entrypoint prints the secret setting DB_PASS:\n%s", entrypoint)
+	}
+	if info, err := os.Stat(result.Entrypoint); err != nil || info.Mode()&0111 == 0 {
+		t.Errorf("Generated entrypoint is not executable: %v", err)
+	}
+
+	if _, err = ScaffoldJob(filepath.Join(dir, "new-job"), opts); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("ScaffoldJob returned %v, expected an error for existing files", err)
+	}
+}
+
+func TestScaffoldSeed(t *testing.T) {
+	cases := []struct {
+		opts   ScaffoldOptions
+		errStr string
+	}{
+		{ScaffoldOptions{Name: "job", JobVersion: "1.0.0"}, ""},
+		{ScaffoldOptions{JobVersion: "1.0.0"}, "A job name and job version are required"},
+		{ScaffoldOptions{Name: "Bad Name", JobVersion: "1.0.0"}, "job.name"},
+		{ScaffoldOptions{Name: "job", JobVersion: "one"}, "job.jobVersion"},
+		{ScaffoldOptions{Name: "job", JobVersion: "1.0.0", Settings: []Setting{{Name: "OUTPUT_DIR"}}}, "reserved variable name"},
+		{ScaffoldOptions{Name: "job", JobVersion: "1.0.0", Resources: Resources{Scalar: []Scalar{{Name: "mem", Value: 256}}}}, ""},
+	}
+
+	for _, c := range cases {
+		_, err := ScaffoldSeed(c.opts)
+		if err == nil && c.errStr != "" {
+			t.Errorf("ScaffoldSeed(%v) did not return an error when one was expected: %v", c.opts, c.errStr)
+		}
+		if err != nil && (c.errStr == "" || !strings.Contains(err.Error(), c.errStr)) {
+			t.Errorf("ScaffoldSeed(%v) returned an error: %v\n expected %v", c.opts, err, c.errStr)
+		}
+	}
+}
@@ -1,11 +1,13 @@
 package registry
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/ngageoint/seed-common/objects"
+	"github.com/ngageoint/seed-common/registry/client"
 	"github.com/ngageoint/seed-common/registry/containeryard"
 	"github.com/ngageoint/seed-common/registry/dockerhub"
 	"github.com/ngageoint/seed-common/registry/v2"
@@ -22,14 +24,53 @@ type RepositoryRegistry interface {
 	RemoveImage(reponame, tag string) error
 }
 
+//ContextRegistry is implemented by registries with context variants of the RepositoryRegistry
+// methods; requests are cancelled when the context is done
+type ContextRegistry interface {
+	PingContext(ctx context.Context) error
+	RepositoriesContext(ctx context.Context) ([]string, error)
+	TagsContext(ctx context.Context, repository string) ([]string, error)
+	ImagesContext(ctx context.Context) ([]string, error)
+	ImagesWithManifestsContext(ctx context.Context) ([]objects.Image, error)
+	GetImageManifestContext(ctx context.Context, repoName, tag string) (string, error)
+	RemoveImageContext(ctx context.Context, reponame, tag string) error
+}
+
+//ConcurrentRegistry is implemented by registries that fetch the manifests of ImagesWithManifests
+// concurrently. ImagesWithManifests skips the images whose manifest could not be read; ImageErrors
+// reports them for the last call.
+type ConcurrentRegistry interface {
+	SetConcurrency(concurrency int)
+	ImageErrors() client.ImageErrors
+}
+
+//CachingRegistry is implemented by registries that can cache the manifests read by
+// GetImageManifest. One client.ManifestCache may be shared by any number of registries.
+type CachingRegistry interface {
+	SetCache(cache *client.ManifestCache)
+}
+
+//PlatformRegistry is implemented by registries that can read the seed manifest of multi-platform
+// images (docker manifest lists and OCI image indexes) for a platform other than linux/amd64
+type PlatformRegistry interface {
+	SetPlatform(platform client.Platform)
+}
+
 type RepoRegistryFactory func(url, org, username, password string) (RepositoryRegistry, error)
 
+//RepoRegistryContextFactory creates a registry, connecting to it with the given context
+type RepoRegistryContextFactory func(ctx context.Context, url, org, username, password string) (RepositoryRegistry, error)
+
 func NewV2Registry(url, org, username, password string) (RepositoryRegistry, error) {
-	v2registry, err := v2.New(url, org, username, password)
-	if err != nil {
+	return NewV2RegistryContext(context.Background(), url, org, username, password)
+}
+
+func NewV2RegistryContext(ctx context.Context, url, org, username, password string) (RepositoryRegistry, error) {
+	v2registry, err := v2.NewContext(ctx, url, org, username, password)
+	if err != nil && ctx.Err() == nil {
 		if strings.Contains(url, "https://") {
 			httpFallback := strings.Replace(url, "https://", "http://", 1)
-			v2registry, err = v2.New(httpFallback, org, username, password)
+			v2registry, err = v2.NewContext(ctx, httpFallback, org, username, password)
 		}
 	}
 
@@ -37,11 +78,15 @@ func NewV2Registry(url, org, username, password string) (RepositoryRegistry, err
 }
 
 func NewDockerHubRegistry(url, org, username, password string) (RepositoryRegistry, error) {
-	hub, err := dockerhub.New(url, org, username, password)
-	if err != nil {
+	return NewDockerHubRegistryContext(context.Background(), url, org, username, password)
+}
+
+func NewDockerHubRegistryContext(ctx context.Context, url, org, username, password string) (RepositoryRegistry, error) {
+	hub, err := dockerhub.NewContext(ctx, url, org, username, password)
+	if err != nil && ctx.Err() == nil {
 		if strings.Contains(url, "https://") {
 			httpFallback := strings.Replace(url, "https://", "http://", 1)
-			hub, err = dockerhub.New(httpFallback, org, username, password)
+			hub, err = dockerhub.NewContext(ctx, httpFallback, org, username, password)
 		}
 	}
 
@@ -49,11 +94,15 @@ func NewDockerHubRegistry(url, org, username, password string) (RepositoryRegist
 }
 
 func NewContainerYardRegistry(url, org, username, password string) (RepositoryRegistry, error) {
-	yard, err := containeryard.New(url, org, username, password)
-	if err != nil {
+	return NewContainerYardRegistryContext(context.Background(), url, org, username, password)
+}
+
+func NewContainerYardRegistryContext(ctx context.Context, url, org, username, password string) (RepositoryRegistry, error) {
+	yard, err := containeryard.NewContext(ctx, url, org, username, password)
+	if err != nil && ctx.Err() == nil {
 		if strings.Contains(url, "https://") {
 			httpFallback := strings.Replace(url, "https://", "http://", 1)
-			yard, err = containeryard.New(httpFallback, org, username, password)
+			yard, err = containeryard.NewContext(ctx, httpFallback, org, username, password)
 		}
 	}
 
@@ -61,39 +110,56 @@ func NewContainerYardRegistry(url, org, username, password string) (RepositoryRe
 }
 
 func CreateRegistry(url, org, username, password string) (RepositoryRegistry, error) {
+	return CreateRegistryContext(context.Background(), url, org, username, password)
+}
+
+//CreateRegistryContext creates a registry of the first type (Container Yard, V2 or docker hub) that
+// responds to a ping at the url, connecting with the given context
+func CreateRegistryContext(ctx context.Context, url, org, username, password string) (RepositoryRegistry, error) {
 	if !strings.HasPrefix(url, "http") {
 		url = "https://" + url
 	}
 
-	yard, err1 := NewContainerYardRegistry(url, org, username, password)
+	yard, err1 := NewContainerYardRegistryContext(ctx, url, org, username, password)
 	if err1 == nil {
-		if yard != nil && yard.Ping() == nil {
+		if err1 = pingContext(ctx, yard); err1 == nil {
 			return yard, nil
-		} else {
-			err1 = yard.Ping()
 		}
 	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
 
-	v2, err2 := NewV2Registry(url, org, username, password)
+	v2, err2 := NewV2RegistryContext(ctx, url, org, username, password)
 	if err2 == nil {
-		if v2 != nil && v2.Ping() == nil {
+		if err2 = pingContext(ctx, v2); err2 == nil {
 			return v2, nil
-		} else {
-			err2 = v2.Ping()
 		}
 	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
 
-	hub, err3 := NewDockerHubRegistry(url, org, username, password)
+	hub, err3 := NewDockerHubRegistryContext(ctx, url, org, username, password)
 	if err3 == nil {
-		if hub != nil && hub.Ping() == nil {
+		if err3 = pingContext(ctx, hub); err3 == nil {
 			return hub, nil
-		} else {
-			err3 = hub.Ping()
 		}
 	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
 
 	msg := fmt.Sprintf("ERROR: Could not create registry. \n Container Yard: %s \n V2: %s \n docker hub: %s \n", err1.Error(), err2.Error(), err3.Error())
 	err := errors.New(msg)
 
 	return nil, err
 }
+
+//pingContext pings the registry with the context if it is a ContextRegistry
+func pingContext(ctx context.Context, registry RepositoryRegistry) error {
+	if r, ok := registry.(ContextRegistry); ok {
+		return r.PingContext(ctx)
+	}
+	return registry.Ping()
+}
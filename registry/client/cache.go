@@ -0,0 +1,290 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	//DefaultTagTTL is how long the config digest a tag resolved to is trusted before it is resolved again
+	DefaultTagTTL = 10 * time.Minute
+
+	//DefaultCacheSize is the default limit, in bytes, of the seed manifests stored in a ManifestCache
+	DefaultCacheSize = 64 * 1024 * 1024
+)
+
+//digestRE matches the sha256 digests used as cache keys
+var digestRE = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+//ManifestCache stores seed manifests on disk by the digest of the image config blob they were read
+// from. Config blobs are immutable so cached manifests never go stale; the config digest each tag
+// resolved to is also cached but only trusted for TTL. When the manifests exceed MaxSize bytes the
+// least recently used ones are evicted. A nil *ManifestCache caches nothing.
+//
+// The cache directory holds manifests/<hex digest>.json and tags/<tag hash>/<image hash>.json where
+// the tag hash is of registry/repository:tag and the image hash of the full image key, so the
+// entries of every platform of a tag are removed together by RemoveTag.
+type ManifestCache struct {
+	Dir     string
+	TTL     time.Duration //tags are always resolved when TTL <= 0
+	MaxSize int64         //manifests are never evicted when MaxSize <= 0
+
+	mutex sync.Mutex
+	sized bool  //whether size has been measured
+	size  int64 //bytes of cached manifests
+}
+
+//tagEntry is the cached config digest of an image tag
+type tagEntry struct {
+	Image   string    `json:"image"`
+	Digest  string    `json:"digest"`
+	Updated time.Time `json:"updated"`
+}
+
+//NewManifestCache creates a cache in the given directory, creating it if necessary
+func NewManifestCache(dir string, ttl time.Duration, maxSize int64) (*ManifestCache, error) {
+	for _, sub := range []string{"manifests", "tags"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0700); err != nil {
+			return nil, err
+		}
+	}
+	return &ManifestCache{Dir: dir, TTL: ttl, MaxSize: maxSize}, nil
+}
+
+//DefaultManifestCacheDir returns the seed manifest cache directory under the user's cache directory
+func DefaultManifestCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "seed", "manifests"), nil
+}
+
+//Manifest returns the cached seed manifest read from the config blob with the given digest
+func (c *ManifestCache) Manifest(digest string) (string, bool) {
+	if c == nil || !digestRE.MatchString(digest) {
+		return "", false
+	}
+	path := c.manifestPath(digest)
+	manifest, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	// mark the manifest as recently used for eviction
+	now := time.Now()
+	os.Chtimes(path, now, now)
+	return string(manifest), true
+}
+
+//PutManifest caches the seed manifest read from the config blob with the given digest, evicting the
+// least recently used manifests if the cache exceeds MaxSize
+func (c *ManifestCache) PutManifest(digest, manifest string) error {
+	if c == nil || !digestRE.MatchString(digest) {
+		return nil
+	}
+	path := c.manifestPath(digest)
+
+	// the size is only counted by the writer that creates the file
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	_, statErr := os.Stat(path)
+	if err := c.writeFile(path, []byte(manifest)); err != nil {
+		return err
+	}
+	if !c.sized {
+		return c.evict()
+	}
+	if os.IsNotExist(statErr) {
+		c.size += int64(len(manifest))
+	}
+	if c.MaxSize > 0 && c.size > c.MaxSize {
+		return c.evict()
+	}
+	return nil
+}
+
+//Digest returns the config digest the image (registry/repository:tag@platform) resolved to if it was
+// cached less than TTL ago
+func (c *ManifestCache) Digest(image string) (string, bool) {
+	if c == nil || c.TTL <= 0 {
+		return "", false
+	}
+	data, err := ioutil.ReadFile(c.tagPath(image))
+	if err != nil {
+		return "", false
+	}
+	var entry tagEntry
+	if json.Unmarshal(data, &entry) != nil || entry.Image != image || time.Since(entry.Updated) > c.TTL {
+		return "", false
+	}
+	return entry.Digest, true
+}
+
+//PutDigest caches the config digest the image (registry/repository:tag@platform) resolved to
+func (c *ManifestCache) PutDigest(image, digest string) error {
+	if c == nil || c.TTL <= 0 || !digestRE.MatchString(digest) {
+		return nil
+	}
+	data, err := json.Marshal(tagEntry{Image: image, Digest: digest, Updated: time.Now()})
+	if err != nil {
+		return err
+	}
+	path := c.tagPath(image)
+	if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return c.writeFile(path, data)
+}
+
+//RemoveDigest removes the cached config digest of the image
+func (c *ManifestCache) RemoveDigest(image string) error {
+	if c == nil {
+		return nil
+	}
+	err := os.Remove(c.tagPath(image))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+//RemoveTag removes the cached config digests of every platform of the tag (registry/repository:tag),
+// i.e. after it is deleted or re-tagged
+func (c *ManifestCache) RemoveTag(tag string) error {
+	if c == nil {
+		return nil
+	}
+	return os.RemoveAll(filepath.Join(c.Dir, "tags", hashKey(tag)))
+}
+
+//Prune removes expired tag entries and evicts the least recently used manifests until the cache is
+// within MaxSize
+func (c *ManifestCache) Prune() error {
+	if c == nil {
+		return nil
+	}
+	tagsDir := filepath.Join(c.Dir, "tags")
+	tags, err := ioutil.ReadDir(tagsDir)
+	if err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		dir := filepath.Join(tagsDir, tag.Name())
+		entries, err := ioutil.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, info := range entries {
+			if c.TTL <= 0 || time.Since(info.ModTime()) > c.TTL {
+				os.Remove(filepath.Join(dir, info.Name()))
+			}
+		}
+		// only removed once every entry of the tag has expired
+		os.Remove(dir)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.evict()
+}
+
+//Clear removes everything from the cache
+func (c *ManifestCache) Clear() error {
+	if c == nil {
+		return nil
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, sub := range []string{"manifests", "tags"} {
+		dir := filepath.Join(c.Dir, sub)
+		if err := os.RemoveAll(dir); err != nil {
+			return err
+		}
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return err
+		}
+	}
+	c.sized, c.size = true, 0
+	return nil
+}
+
+//evict measures the cached manifests and removes the least recently used ones until they fit in
+// MaxSize. The mutex must be held.
+func (c *ManifestCache) evict() error {
+	dir := filepath.Join(c.Dir, "manifests")
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	c.sized, c.size = true, 0
+	for _, info := range files {
+		c.size += info.Size()
+	}
+	if c.MaxSize <= 0 || c.size <= c.MaxSize {
+		return nil
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+	for _, info := range files {
+		if c.size <= c.MaxSize {
+			break
+		}
+		if err = os.Remove(filepath.Join(dir, info.Name())); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		c.size -= info.Size()
+	}
+	return nil
+}
+
+func (c *ManifestCache) manifestPath(digest string) string {
+	return filepath.Join(c.Dir, "manifests", digest[len("sha256:"):]+".json")
+}
+
+//tagPath returns the file of an image key (registry/repository:tag@platform) in the directory of
+// its tag
+func (c *ManifestCache) tagPath(image string) string {
+	tag := image
+	if i := strings.LastIndex(image, "@"); i >= 0 {
+		tag = image[:i]
+	}
+	return filepath.Join(c.Dir, "tags", hashKey(tag), hashKey(image)+".json")
+}
+
+//hashKey returns the hex sha256 hash of a cache key for use as a file name
+func hashKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+//writeFile writes the file through a temporary file in the cache directory so concurrent readers
+// never see a partial write
+func (c *ManifestCache) writeFile(path string, data []byte) error {
+	tmp, err := ioutil.TempFile(c.Dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(data); err == nil {
+		err = tmp.Close()
+	} else {
+		tmp.Close()
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
@@ -0,0 +1,165 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSeedManifestCache(t *testing.T) {
+	server := fakeRegistry()
+	defer server.Close()
+	reg, err := New(context.Background(), server.URL, "", "")
+	if err != nil {
+		t.Fatalf("New returned an error: %v", err)
+	}
+
+	dir, err := ioutil.TempDir("", "manifestcache")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	cache, err := NewManifestCache(dir, time.Hour, DefaultCacheSize)
+	if err != nil {
+		t.Fatalf("NewManifestCache returned an error: %v", err)
+	}
+
+	manifestPath := "/v2/my-job-seed/manifests/1.0.0"
+	blobPath := "/v2/my-job-seed/blobs/" + configDigest
+	manifests, blobs := requests(manifestPath), requests(blobPath)
+
+	cases := []struct {
+		tag       string
+		expire    bool
+		manifests int
+		blobs     int
+	}{
+		{"1.0.0", false, 1, 1},  //first read downloads the manifest and config blob
+		{"1.0.0", false, 1, 1},  //the tag's digest and manifest are cached
+		{"latest", false, 1, 1}, //a new tag with a cached config digest only needs its manifest
+		{"1.0.0", true, 2, 1},   //an expired tag is resolved again but its config blob is cached
+	}
+
+	for i, c := range cases {
+		if c.expire {
+			cache.TTL = time.Nanosecond
+			time.Sleep(time.Millisecond)
+		}
+		manifest, err := SeedManifest(context.Background(), reg, cache, Platform{}, "my-job-seed", c.tag)
+		cache.TTL = time.Hour
+		if err != nil || manifest != `{"seedVersion": "1.0.0"}` {
+			t.Errorf("case %d: SeedManifest returned %v, %v", i, manifest, err)
+		}
+		if requests(manifestPath)-manifests != c.manifests || requests(blobPath)-blobs != c.blobs {
+			t.Errorf("case %d: SeedManifest requested the manifest %d and blob %d times, expected %d and %d", i,
+				requests(manifestPath)-manifests, requests(blobPath)-blobs, c.manifests, c.blobs)
+		}
+	}
+
+	if _, ok := cache.Digest(imageKey(reg, Platform{}, "my-job-seed", "1.0.0")); !ok {
+		t.Errorf("SeedManifest did not cache the config digest of the tag")
+	}
+	cache.RemoveDigest(imageKey(reg, Platform{}, "my-job-seed", "1.0.0"))
+	if _, ok := cache.Digest(imageKey(reg, Platform{}, "my-job-seed", "1.0.0")); ok {
+		t.Errorf("RemoveDigest did not remove the config digest of the tag")
+	}
+
+	arm := Platform{OS: "linux", Architecture: "arm64"}
+	for _, image := range []string{imageKey(reg, Platform{}, "my-job-seed", "1.0.0"),
+		imageKey(reg, arm, "my-job-seed", "1.0.0"), imageKey(reg, arm, "my-job-seed", "1.0.00")} {
+		cache.PutDigest(image, configDigest)
+	}
+	if err = cache.RemoveTag(tagKey(reg, "my-job-seed", "1.0.0")); err != nil {
+		t.Errorf("RemoveTag returned an error: %v", err)
+	}
+	for _, c := range []struct {
+		image  string
+		cached bool
+	}{
+		{imageKey(reg, Platform{}, "my-job-seed", "1.0.0"), false},
+		{imageKey(reg, arm, "my-job-seed", "1.0.0"), false},
+		{imageKey(reg, arm, "my-job-seed", "1.0.00"), true},
+	} {
+		if _, ok := cache.Digest(c.image); ok != c.cached {
+			t.Errorf("After RemoveTag the digest of %s cached: %v, expected %v", c.image, ok, c.cached)
+		}
+	}
+}
+
+func TestManifestCacheEviction(t *testing.T) {
+	dir, err := ioutil.TempDir("", "manifestcache")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	cache, _ := NewManifestCache(dir, time.Hour, 100)
+
+	digest := func(c string) string { return "sha256:" + strings.Repeat(c, 64) }
+	manifest := strings.Repeat("m", 40)
+	cache.PutManifest(digest("a"), manifest)
+	cache.PutManifest(digest("b"), manifest)
+
+	// make a the most recently used
+	past := time.Now().Add(-time.Hour)
+	os.Chtimes(cache.manifestPath(digest("a")), past, past)
+	os.Chtimes(cache.manifestPath(digest("b")), past.Add(-time.Minute), past.Add(-time.Minute))
+	if _, ok := cache.Manifest(digest("a")); !ok {
+		t.Errorf("Manifest did not return a cached manifest")
+	}
+
+	cache.PutManifest(digest("c"), manifest)
+	for _, c := range []struct {
+		name   string
+		cached bool
+	}{{"a", true}, {"b", false}, {"c", true}} {
+		if _, ok := cache.Manifest(digest(c.name)); ok != c.cached {
+			t.Errorf("After eviction manifest %s cached: %v, expected %v", c.name, ok, c.cached)
+		}
+	}
+
+	cache.PutManifest("sha256:../../escape", manifest)
+	cache.PutDigest("image", "not-a-digest")
+	if files, _ := ioutil.ReadDir(dir); len(files) != 2 {
+		t.Errorf("Cache wrote invalid digests: %v", files)
+	}
+
+	cache.PutDigest("image", digest("a"))
+	cache.TTL = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	if err = cache.Prune(); err != nil {
+		t.Errorf("Prune returned an error: %v", err)
+	}
+	if tags, _ := ioutil.ReadDir(filepath.Join(dir, "tags")); len(tags) != 0 {
+		t.Errorf("Prune did not remove expired tags: %v", tags)
+	}
+
+	// concurrent writes of the same manifest are only counted once
+	cache.Clear()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cache.PutManifest(digest("d"), manifest)
+		}()
+	}
+	wg.Wait()
+	if cache.size != int64(len(manifest)) {
+		t.Errorf("Concurrent PutManifest counted %d bytes, expected %d", cache.size, len(manifest))
+	}
+
+	var nilCache *ManifestCache
+	if _, ok := nilCache.Manifest(digest("a")); ok || nilCache.PutManifest(digest("a"), manifest) != nil {
+		t.Errorf("A nil cache cached a manifest")
+	}
+
+	cache.Clear()
+	if _, ok := cache.Manifest(digest("a")); ok {
+		t.Errorf("Clear did not remove the cached manifests")
+	}
+}
@@ -0,0 +1,288 @@
+//Package client makes the docker registry v2 API calls used by the registry backends with a
+// context.Context so that slow requests can be cancelled or given deadlines. Requests are sent
+// through the authenticating http.Client of a heroku docker-registry-client Registry, which does
+// not accept a context itself.
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/heroku/docker-registry-client/registry"
+	"github.com/ngageoint/seed-common/objects"
+	"github.com/ngageoint/seed-common/util"
+)
+
+//MediaTypeManifestV2 is the media type of a docker image manifest, schema version 2
+const MediaTypeManifestV2 = "application/vnd.docker.distribution.manifest.v2+json"
+
+//ErrNoMorePages is returned by GetPaginatedJson when there is no next page
+var ErrNoMorePages = errors.New("No more pages")
+
+//Descriptor references a blob or manifest by media type and digest. Platform is only set for the
+// manifests of a manifest list or image index.
+type Descriptor struct {
+	MediaType string    `json:"mediaType"`
+	Size      int64     `json:"size"`
+	Digest    string    `json:"digest"`
+	Platform  *Platform `json:"platform,omitempty"`
+}
+
+//Manifest is an image manifest (docker schema 2 or OCI) or, when Manifests is set, a docker
+// manifest list or OCI image index
+type Manifest struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType"`
+	Config        Descriptor   `json:"config"`
+	Layers        []Descriptor `json:"layers"`
+	Manifests     []Descriptor `json:"manifests"`
+}
+
+//New creates a registry client for the given URL and credentials like registry.New, pinging the
+// registry with the given context before returning it
+func New(ctx context.Context, registryUrl, username, password string) (*registry.Registry, error) {
+	url := strings.TrimSuffix(registryUrl, "/")
+	reg := &registry.Registry{
+		URL:    url,
+		Client: &http.Client{Transport: registry.WrapTransport(http.DefaultTransport, url, username, password)},
+		Logf:   registry.Quiet,
+	}
+	if err := Ping(ctx, reg); err != nil {
+		return nil, err
+	}
+	return reg, nil
+}
+
+//Do sends a request with the given context, method, url and headers using the http client
+func Do(ctx context.Context, client *http.Client, method, url string, header map[string]string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range header {
+		req.Header.Set(key, value)
+	}
+	return client.Do(req)
+}
+
+//GetJson gets the url with the given context and decodes the JSON response. The response headers
+// are returned so pagination links can be followed.
+func GetJson(ctx context.Context, client *http.Client, url string, response interface{}) (http.Header, error) {
+	resp, err := Do(ctx, client, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err = json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, err
+	}
+	return resp.Header, nil
+}
+
+//nextLinkRE matches an RFC 5988 Link header with rel="next"
+var nextLinkRE = regexp.MustCompile(`^ *<?([^;>]+)>? *(?:;[^;]*)*; *rel="?next"?(?:;.*)?`)
+
+//GetPaginatedJson gets a page of a registry v2 listing, returning the absolute URL of the next
+// page from the Link header or ErrNoMorePages
+func GetPaginatedJson(ctx context.Context, reg *registry.Registry, url string, response interface{}) (string, error) {
+	header, err := GetJson(ctx, reg.Client, url, response)
+	if err != nil {
+		return "", err
+	}
+	for _, link := range header[http.CanonicalHeaderKey("Link")] {
+		if parts := nextLinkRE.FindStringSubmatch(link); parts != nil {
+			next := parts[1]
+			if !strings.HasPrefix(next, "http") {
+				next = reg.URL + next
+			}
+			return next, nil
+		}
+	}
+	return "", ErrNoMorePages
+}
+
+//Ping checks the registry is available and the credentials are accepted
+func Ping(ctx context.Context, reg *registry.Registry) error {
+	url := reg.URL + "/v2/"
+	reg.Logf("registry.ping url=%s", url)
+	resp, err := Do(ctx, reg.Client, "GET", url, nil)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	return err
+}
+
+//Repositories returns every repository in the registry catalog
+func Repositories(ctx context.Context, reg *registry.Registry) ([]string, error) {
+	url := reg.URL + "/v2/_catalog"
+	repos := make([]string, 0, 10)
+	for {
+		reg.Logf("registry.repositories url=%s", url)
+		var response struct {
+			Repositories []string `json:"repositories"`
+		}
+		next, err := GetPaginatedJson(ctx, reg, url, &response)
+		if err != nil && err != ErrNoMorePages {
+			return nil, err
+		}
+		repos = append(repos, response.Repositories...)
+		if err == ErrNoMorePages {
+			return repos, nil
+		}
+		url = next
+	}
+}
+
+//Tags returns the tags of a repository
+func Tags(ctx context.Context, reg *registry.Registry, repository string) ([]string, error) {
+	url := fmt.Sprintf("%s/v2/%s/tags/list", reg.URL, repository)
+	tags := []string{}
+	for {
+		reg.Logf("registry.tags url=%s repository=%s", url, repository)
+		var response struct {
+			Tags []string `json:"tags"`
+		}
+		next, err := GetPaginatedJson(ctx, reg, url, &response)
+		if err != nil && err != ErrNoMorePages {
+			return nil, err
+		}
+		tags = append(tags, response.Tags...)
+		if err == ErrNoMorePages {
+			return tags, nil
+		}
+		url = next
+	}
+}
+
+//ManifestDigest returns the digest of the manifest, manifest list or image index a tag refers to
+func ManifestDigest(ctx context.Context, reg *registry.Registry, repository, reference string) (string, error) {
+	url := fmt.Sprintf("%s/v2/%s/manifests/%s", reg.URL, repository, reference)
+	reg.Logf("registry.manifest.head url=%s repository=%s reference=%s", url, repository, reference)
+	resp, err := Do(ctx, reg.Client, "HEAD", url, map[string]string{"Accept": manifestAccept})
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		return "", err
+	}
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if !strings.Contains(digest, ":") {
+		msg := fmt.Sprintf("ERROR: Invalid manifest digest %q for %s:%s", digest, repository, reference)
+		return "", errors.New(msg)
+	}
+	return digest, nil
+}
+
+//DeleteManifest deletes the manifest with the given digest from a repository
+func DeleteManifest(ctx context.Context, reg *registry.Registry, repository, digest string) error {
+	url := fmt.Sprintf("%s/v2/%s/manifests/%s", reg.URL, repository, digest)
+	reg.Logf("registry.manifest.delete url=%s repository=%s reference=%s", url, repository, digest)
+	resp, err := Do(ctx, reg.Client, "DELETE", url, nil)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	return err
+}
+
+//DownloadLayer returns the contents of a blob. The caller must close it.
+func DownloadLayer(ctx context.Context, reg *registry.Registry, repository, digest string) (io.ReadCloser, error) {
+	url := fmt.Sprintf("%s/v2/%s/blobs/%s", reg.URL, repository, digest)
+	reg.Logf("registry.layer.download url=%s repository=%s digest=%s", url, repository, digest)
+	resp, err := Do(ctx, reg.Client, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+//SeedManifest returns the seed manifest from the labels of an image's config blob. Manifest lists
+// and image indexes are resolved to the image for the given platform (DefaultPlatform if empty). If
+// a cache is given the config digest of a recently resolved tag and the manifests of previously
+// read config blobs are taken from it, so only new or changed tags are downloaded.
+func SeedManifest(ctx context.Context, reg *registry.Registry, cache *ManifestCache, platform Platform, repository, tag string) (string, error) {
+	image := imageKey(reg, platform, repository, tag)
+	if digest, ok := cache.Digest(image); ok {
+		if manifest, ok := cache.Manifest(digest); ok {
+			return manifest, nil
+		}
+	}
+
+	imageManifest, err := ImageManifest(ctx, reg, repository, tag, platform)
+	if err != nil {
+		return "", err
+	}
+	digest := imageManifest.Config.Digest
+	manifest, ok := cache.Manifest(digest)
+	if !ok {
+		if manifest, err = configManifest(ctx, reg, repository, digest); err != nil {
+			return "", err
+		}
+		if err = cache.PutManifest(digest, manifest); err != nil {
+			util.PrintUtil("WARN: Unable to cache the seed manifest of %s. %s\n", image, err.Error())
+		}
+	}
+	if err = cache.PutDigest(image, digest); err != nil {
+		util.PrintUtil("WARN: Unable to cache the config digest of %s. %s\n", image, err.Error())
+	}
+	return manifest, nil
+}
+
+//configManifest downloads a config blob, verifies it matches its digest and returns the seed
+// manifest from its labels
+func configManifest(ctx context.Context, reg *registry.Registry, repository, digest string) (string, error) {
+	blob, err := DownloadLayer(ctx, reg, repository, digest)
+	if err != nil {
+		return "", err
+	}
+	body, err := ioutil.ReadAll(blob)
+	blob.Close()
+	if err != nil {
+		return "", err
+	}
+	if digestRE.MatchString(digest) {
+		if sum := sha256.Sum256(body); digest != "sha256:"+hex.EncodeToString(sum[:]) {
+			msg := fmt.Sprintf("ERROR: Config blob of %s does not match its digest %s", repository, digest)
+			return "", errors.New(msg)
+		}
+	}
+
+	manifest, err := objects.GetSeedManifestFromBlob(ioutil.NopCloser(bytes.NewReader(body)))
+	if err == nil && manifest == "" {
+		err = errors.New("Empty seed manifest!")
+	}
+	return manifest, err
+}
+
+//RemoveImage deletes the manifest (or the manifest list or image index) of an image by tag,
+// forgetting the config digests cached for every platform of the tag
+func RemoveImage(ctx context.Context, reg *registry.Registry, cache *ManifestCache, repository, tag string) error {
+	digest, err := ManifestDigest(ctx, reg, repository, tag)
+	if err == nil {
+		err = DeleteManifest(ctx, reg, repository, digest)
+	}
+	if err == nil {
+		err = cache.RemoveTag(tagKey(reg, repository, tag))
+	}
+	return err
+}
+
+//tagKey identifies a tag across registries in the cache
+func tagKey(reg *registry.Registry, repository, tag string) string {
+	return reg.URL + "/" + repository + ":" + tag
+}
+
+//imageKey identifies the image of a tag for a platform across registries in the cache
+func imageKey(reg *registry.Registry, platform Platform, repository, tag string) string {
+	return tagKey(reg, repository, tag) + "@" + platform.orDefault().String()
+}
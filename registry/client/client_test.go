@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ngageoint/seed-common/util"
+)
+
+const configBlob = `{"config": {"Labels": {"com.ngageoint.seed.manifest": "{\"seedVersion\": \"1.0.0\"}"}}}`
+
+var configDigest = func() string {
+	sum := sha256.Sum256([]byte(configBlob))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}()
+
+//badDigest is referenced by a manifest but does not match the config blob served for it
+var badDigest = "sha256:" + strings.Repeat("0", 64)
+
+//fakeRequests counts the requests to each path of the fake registries
+var fakeRequests = struct {
+	sync.Mutex
+	paths map[string]int
+}{paths: make(map[string]int)}
+
+func requests(path string) int {
+	fakeRequests.Lock()
+	defer fakeRequests.Unlock()
+	return fakeRequests.paths[path]
+}
+
+//fakeRegistry serves a paginated tag list, schema 2 manifests and their config blob
+func fakeRegistry() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/", func(w http.ResponseWriter, r *http.Request) {
+		fakeRequests.Lock()
+		fakeRequests.paths[r.URL.Path]++
+		fakeRequests.Unlock()
+
+		switch r.URL.Path {
+		case "/v2/":
+			fmt.Fprint(w, "{}")
+		case "/v2/my-job-seed/tags/list":
+			if r.URL.Query().Get("last") == "" {
+				w.Header().Set("Link", `</v2/my-job-seed/tags/list?n=2&last=1.0.0>; rel="next"`)
+				fmt.Fprint(w, `{"tags": ["0.1.0", "1.0.0"]}`)
+			} else {
+				fmt.Fprint(w, `{"tags": ["2.0.0"]}`)
+			}
+		case "/v2/my-job-seed/manifests/1.0.0", "/v2/my-job-seed/manifests/latest", "/v2/my-job-seed/manifests/2.0.0":
+			if !strings.Contains(r.Header.Get("Accept"), MediaTypeManifestV2) {
+				http.Error(w, "unexpected accept header", http.StatusBadRequest)
+				return
+			}
+			digest := configDigest
+			if strings.HasSuffix(r.URL.Path, "2.0.0") {
+				digest = badDigest
+			}
+			fmt.Fprintf(w, `{"schemaVersion": 2, "mediaType": %q, "config": {"digest": %q}}`, MediaTypeManifestV2, digest)
+		case "/v2/my-job-seed/blobs/" + configDigest, "/v2/my-job-seed/blobs/" + badDigest:
+			fmt.Fprint(w, configBlob)
+		case "/v2/slow-seed/tags/list":
+			select {
+			case <-r.Context().Done():
+			case <-time.After(2 * time.Second):
+				fmt.Fprint(w, `{"tags": []}`)
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestClient(t *testing.T) {
+	util.InitPrinter(util.Quiet, nil, nil)
+	server := fakeRegistry()
+	defer server.Close()
+
+	reg, err := New(context.Background(), server.URL, "", "")
+	if err != nil {
+		t.Fatalf("New returned an error: %v", err)
+	}
+
+	tags, err := Tags(context.Background(), reg, "my-job-seed")
+	if expected := []string{"0.1.0", "1.0.0", "2.0.0"}; err != nil || !reflect.DeepEqual(tags, expected) {
+		t.Errorf("Tags returned %v, %v, expected %v", tags, err, expected)
+	}
+
+	manifest, err := SeedManifest(context.Background(), reg, nil, Platform{}, "my-job-seed", "1.0.0")
+	if err != nil || manifest != `{"seedVersion": "1.0.0"}` {
+		t.Errorf("SeedManifest returned %v, %v", manifest, err)
+	}
+
+	_, err = SeedManifest(context.Background(), reg, nil, Platform{}, "my-job-seed", "9.9.9")
+	if err == nil || !strings.Contains(err.Error(), "status=404") {
+		t.Errorf("SeedManifest of a missing tag returned %v, expected a 404 error", err)
+	}
+
+	_, err = SeedManifest(context.Background(), reg, nil, Platform{}, "my-job-seed", "2.0.0")
+	if err == nil || !strings.Contains(err.Error(), "does not match its digest") {
+		t.Errorf("SeedManifest of a corrupt config blob returned %v, expected a digest error", err)
+	}
+}
+
+func TestClientContext(t *testing.T) {
+	server := fakeRegistry()
+	defer server.Close()
+	reg, err := New(context.Background(), server.URL, "", "")
+	if err != nil {
+		t.Fatalf("New returned an error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = Tags(ctx, reg, "slow-seed")
+	if err == nil || !strings.Contains(err.Error(), "context deadline exceeded") || time.Since(start) > time.Second {
+		t.Errorf("Tags returned %v after %v, expected the deadline to cancel the request", err, time.Since(start))
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = New(cancelled, server.URL, "", ""); err == nil || !strings.Contains(err.Error(), "context canceled") {
+		t.Errorf("New with a cancelled context returned %v", err)
+	}
+}
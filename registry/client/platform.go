@@ -0,0 +1,155 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/heroku/docker-registry-client/registry"
+	"github.com/ngageoint/seed-common/util"
+)
+
+//Manifest media types understood by ImageManifest
+const (
+	MediaTypeManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	MediaTypeOCIManifest  = "application/vnd.oci.image.manifest.v1+json"
+	MediaTypeOCIIndex     = "application/vnd.oci.image.index.v1+json"
+)
+
+//manifestAccept lists every manifest media type in the Accept header of manifest requests
+var manifestAccept = strings.Join([]string{MediaTypeManifestV2, MediaTypeManifestList, MediaTypeOCIManifest, MediaTypeOCIIndex}, ", ")
+
+//maxIndexDepth limits how many nested indexes ImageManifest follows
+const maxIndexDepth = 2
+
+//Platform identifies the operating system and architecture an image in an index is built for
+type Platform struct {
+	OS           string `json:"os"`
+	Architecture string `json:"architecture"`
+	Variant      string `json:"variant,omitempty"`
+}
+
+//DefaultPlatform is the platform whose image is read from indexes and manifest lists by default
+var DefaultPlatform = Platform{OS: "linux", Architecture: "amd64"}
+
+//ParsePlatform parses a platform in the os/architecture[/variant] form used by docker, i.e. linux/arm64/v8
+func ParsePlatform(platform string) (Platform, error) {
+	parts := strings.Split(strings.ToLower(strings.TrimSpace(platform)), "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		msg := fmt.Sprintf("ERROR: Invalid platform %q; expected os/architecture[/variant]", platform)
+		return Platform{}, errors.New(msg)
+	}
+	p := Platform{OS: parts[0], Architecture: parts[1]}
+	if len(parts) == 3 {
+		p.Variant = parts[2]
+	}
+	return p, nil
+}
+
+//String formats the platform as os/architecture[/variant]
+func (p Platform) String() string {
+	if p.Variant != "" {
+		return p.OS + "/" + p.Architecture + "/" + p.Variant
+	}
+	return p.OS + "/" + p.Architecture
+}
+
+//Matches checks if an image built for the other platform can be used for this one. The variant is
+// only compared when this platform specifies one.
+func (p Platform) Matches(other Platform) bool {
+	return p.OS == other.OS && p.Architecture == other.Architecture && (p.Variant == "" || p.Variant == other.Variant)
+}
+
+//orDefault returns DefaultPlatform for an empty platform
+func (p Platform) orDefault() Platform {
+	if p.OS == "" && p.Architecture == "" {
+		return DefaultPlatform
+	}
+	return p
+}
+
+//isIndex checks if a media type is a manifest list or image index
+func isIndex(mediaType string) bool {
+	return mediaType == MediaTypeManifestList || mediaType == MediaTypeOCIIndex
+}
+
+//GetManifest returns a manifest, manifest list or image index by tag or digest, accepting docker
+// schema 2 and OCI media types. The media type of the result is always set.
+func GetManifest(ctx context.Context, reg *registry.Registry, repository, reference string) (*Manifest, error) {
+	url := fmt.Sprintf("%s/v2/%s/manifests/%s", reg.URL, repository, reference)
+	reg.Logf("registry.manifest.get url=%s repository=%s reference=%s", url, repository, reference)
+	resp, err := Do(ctx, reg.Client, "GET", url, map[string]string{"Accept": manifestAccept})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &Manifest{}
+	if err = json.Unmarshal(body, manifest); err != nil {
+		return nil, err
+	}
+	if manifest.SchemaVersion != 2 {
+		msg := fmt.Sprintf("ERROR: Unsupported manifest schema version %d for %s:%s", manifest.SchemaVersion, repository, reference)
+		return nil, errors.New(msg)
+	}
+
+	// the mediaType field is optional for OCI manifests and indexes
+	if manifest.MediaType == "" {
+		manifest.MediaType = util.BaseMediaType(resp.Header.Get("Content-Type"))
+	}
+	switch manifest.MediaType {
+	case MediaTypeManifestV2, MediaTypeManifestList, MediaTypeOCIManifest, MediaTypeOCIIndex:
+	case "", "application/json", "application/octet-stream", "text/plain":
+		if len(manifest.Manifests) > 0 {
+			manifest.MediaType = MediaTypeOCIIndex
+		} else {
+			manifest.MediaType = MediaTypeOCIManifest
+		}
+	default:
+		msg := fmt.Sprintf("ERROR: Unsupported manifest media type %s for %s:%s", manifest.MediaType, repository, reference)
+		return nil, errors.New(msg)
+	}
+	return manifest, nil
+}
+
+//ImageManifest returns the image manifest of a tag or digest. Manifest lists and image indexes are
+// resolved to the image built for the given platform, or DefaultPlatform if it is empty.
+func ImageManifest(ctx context.Context, reg *registry.Registry, repository, reference string, platform Platform) (*Manifest, error) {
+	platform = platform.orDefault()
+	tag := reference
+	for depth := 0; ; depth++ {
+		manifest, err := GetManifest(ctx, reg, repository, reference)
+		if err != nil || !isIndex(manifest.MediaType) {
+			return manifest, err
+		}
+		if depth == maxIndexDepth {
+			msg := fmt.Sprintf("ERROR: Too many nested indexes for %s:%s", repository, tag)
+			return nil, errors.New(msg)
+		}
+
+		available := []string{}
+		reference = ""
+		for _, m := range manifest.Manifests {
+			if m.Platform == nil {
+				continue
+			}
+			if platform.Matches(*m.Platform) {
+				reference = m.Digest
+				break
+			}
+			available = append(available, m.Platform.String())
+		}
+		if reference == "" {
+			msg := fmt.Sprintf("ERROR: No image for platform %s in %s:%s; available platforms: %s", platform,
+				repository, tag, strings.Join(available, ", "))
+			return nil, errors.New(msg)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func digestOf(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+//fakeMultiPlatformRegistry serves a docker manifest list and an OCI index, each referring to OCI
+// image manifests for linux/amd64 and linux/arm64/v8 whose config blobs hold different seed manifests
+func fakeMultiPlatformRegistry() *httptest.Server {
+	blobs := map[string]string{}
+	manifests := map[string]string{}
+	contentTypes := map[string]string{}
+
+	images := []string{}
+	for _, platform := range []Platform{{"linux", "amd64", ""}, {"linux", "arm64", "v8"}} {
+		config := fmt.Sprintf(`{"config": {"Labels": {"com.ngageoint.seed.manifest": "{\"arch\": \"%s\"}"}}}`, platform.Architecture)
+		blobs[digestOf(config)] = config
+		// OCI manifests may omit their media type
+		manifest := fmt.Sprintf(`{"schemaVersion": 2, "config": {"mediaType": "application/vnd.oci.image.config.v1+json", "digest": %q}}`, digestOf(config))
+		manifests[digestOf(manifest)] = manifest
+		contentTypes[digestOf(manifest)] = MediaTypeOCIManifest
+		images = append(images, fmt.Sprintf(`{"mediaType": %q, "digest": %q, "platform": {"os": "linux", "architecture": %q, "variant": %q}}`,
+			MediaTypeOCIManifest, digestOf(manifest), platform.Architecture, platform.Variant))
+	}
+	// attestation manifests have an unknown platform
+	images = append(images, `{"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:0", "platform": {"os": "unknown", "architecture": "unknown"}}`)
+
+	manifests["list"] = fmt.Sprintf(`{"schemaVersion": 2, "mediaType": %q, "manifests": [%s]}`, MediaTypeManifestList, strings.Join(images, ", "))
+	contentTypes["list"] = MediaTypeManifestList
+	manifests["index"] = fmt.Sprintf(`{"schemaVersion": 2, "manifests": [%s]}`, strings.Join(images, ", "))
+	contentTypes["index"] = MediaTypeOCIIndex
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(r.URL.Path, "/")
+		reference := parts[len(parts)-1]
+		switch {
+		case r.URL.Path == "/v2/":
+			fmt.Fprint(w, "{}")
+		case strings.HasPrefix(r.URL.Path, "/v2/multi-seed/manifests/") && manifests[reference] != "":
+			w.Header().Set("Content-Type", contentTypes[reference])
+			fmt.Fprint(w, manifests[reference])
+		case strings.HasPrefix(r.URL.Path, "/v2/multi-seed/blobs/") && blobs[reference] != "":
+			fmt.Fprint(w, blobs[reference])
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestMultiPlatformSeedManifest(t *testing.T) {
+	server := fakeMultiPlatformRegistry()
+	defer server.Close()
+	reg, err := New(context.Background(), server.URL, "", "")
+	if err != nil {
+		t.Fatalf("New returned an error: %v", err)
+	}
+
+	cases := []struct {
+		tag      string
+		platform string
+		manifest string
+		errStr   string
+	}{
+		{"list", "", `{"arch": "amd64"}`, ""},
+		{"index", "", `{"arch": "amd64"}`, ""},
+		{"list", "linux/arm64", `{"arch": "arm64"}`, ""},
+		{"index", "linux/arm64/v8", `{"arch": "arm64"}`, ""},
+		{"index", "linux/arm64/v7", "", "No image for platform linux/arm64/v7 in multi-seed:index; available platforms: linux/amd64, linux/arm64/v8, unknown/unknown"},
+		{"list", "windows/amd64", "", "No image for platform windows/amd64"},
+	}
+
+	for _, c := range cases {
+		platform := Platform{}
+		if c.platform != "" {
+			if platform, err = ParsePlatform(c.platform); err != nil {
+				t.Fatalf("ParsePlatform(%q) returned an error: %v", c.platform, err)
+			}
+		}
+		manifest, err := SeedManifest(context.Background(), reg, nil, platform, "multi-seed", c.tag)
+		if manifest != c.manifest {
+			t.Errorf("SeedManifest(%v, %v) returned %v, expected %v", c.tag, c.platform, manifest, c.manifest)
+		}
+		if err == nil && c.errStr != "" {
+			t.Errorf("SeedManifest(%v, %v) did not return an error when one was expected: %v", c.tag, c.platform, c.errStr)
+		}
+		if err != nil && (c.errStr == "" || !strings.Contains(err.Error(), c.errStr)) {
+			t.Errorf("SeedManifest(%v, %v) returned an error: %v\n expected %v", c.tag, c.platform, err, c.errStr)
+		}
+	}
+}
+
+func TestParsePlatform(t *testing.T) {
+	cases := []struct {
+		input    string
+		platform Platform
+		errStr   string
+	}{
+		{"linux/amd64", Platform{"linux", "amd64", ""}, ""},
+		{" Linux/ARM64/v8 ", Platform{"linux", "arm64", "v8"}, ""},
+		{"linux", Platform{}, "Invalid platform"},
+		{"linux//v8", Platform{}, "Invalid platform"},
+		{"linux/arm/v7/extra", Platform{}, "Invalid platform"},
+	}
+
+	for _, c := range cases {
+		platform, err := ParsePlatform(c.input)
+		if platform != c.platform {
+			t.Errorf("ParsePlatform(%q) returned %v, expected %v", c.input, platform, c.platform)
+		}
+		if (err != nil) != (c.errStr != "") || (err != nil && !strings.Contains(err.Error(), c.errStr)) {
+			t.Errorf("ParsePlatform(%q) returned error %v, expected %v", c.input, err, c.errStr)
+		}
+	}
+}
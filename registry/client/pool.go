@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+//DefaultConcurrency is the number of image manifests fetched at once when no concurrency is configured
+const DefaultConcurrency = 8
+
+//ManifestFetcher returns the seed manifest of an image, i.e. RepositoryRegistry.GetImageManifestContext
+type ManifestFetcher func(ctx context.Context, repoName, tag string) (string, error)
+
+//ImageError is the error reading the manifest of a single image
+type ImageError struct {
+	Image string
+	Err   error
+}
+
+func (e ImageError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Image, e.Err.Error())
+}
+
+//ImageErrors lists the images whose manifests ImagesWithManifests could not read; the images that
+// were read are still returned by it
+type ImageErrors []ImageError
+
+func (e ImageErrors) Error() string {
+	problems := []string{}
+	for _, imageErr := range e {
+		problems = append(problems, imageErr.Error())
+	}
+	return fmt.Sprintf("ERROR: Unable to read the manifests of %d images:\n%s", len(e), strings.Join(problems, "\n"))
+}
+
+//FetchManifests fetches the manifests of the given images (repo:tag) with at most concurrency fetches
+// in flight; DefaultConcurrency is used if concurrency is less than 1. The returned manifests and
+// errors are in the same order as images. Images not yet fetched when the context is done get the
+// context's error.
+func FetchManifests(ctx context.Context, images []string, concurrency int, fetch ManifestFetcher) ([]string, []error) {
+	manifests := make([]string, len(images))
+	errs := make([]error, len(images))
+	if concurrency < 1 {
+		concurrency = DefaultConcurrency
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < len(images); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				// each worker writes only the index it received, so no locking is needed
+				manifests[i], errs[i] = fetchManifest(ctx, images[i], fetch)
+			}
+		}()
+	}
+	for i := range images {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return manifests, errs
+}
+
+func fetchManifest(ctx context.Context, image string, fetch ManifestFetcher) (string, error) {
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+	temp := strings.Split(image, ":")
+	if len(temp) != 2 {
+		msg := fmt.Sprintf("ERROR: Invalid seed name: %s. Unable to split into name/tag pair", image)
+		return "", errors.New(msg)
+	}
+	return fetch(ctx, temp[0], temp[1])
+}
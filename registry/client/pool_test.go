@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFetchManifests(t *testing.T) {
+	images := []string{"a-seed:1", "b-seed:1", "bad", "c-seed:2", "missing-seed:1", "d-seed:3"}
+
+	cases := []struct {
+		concurrency int
+		maxInFlight int32
+	}{
+		{0, DefaultConcurrency},
+		{1, 1},
+		{3, 3},
+	}
+
+	for _, c := range cases {
+		var inFlight, maxInFlight int32
+		fetch := func(ctx context.Context, repoName, tag string) (string, error) {
+			n := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				max := atomic.LoadInt32(&maxInFlight)
+				if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+					break
+				}
+			}
+			// finish later images first to check the results are not in completion order
+			time.Sleep(time.Duration(len(repoName)) * time.Millisecond)
+			if strings.HasPrefix(repoName, "missing") {
+				return "", errors.New("Empty seed manifest!")
+			}
+			return repoName + "@" + tag, nil
+		}
+
+		manifests, errs := FetchManifests(context.Background(), images, c.concurrency, fetch)
+		expected := []string{"a-seed@1", "b-seed@1", "", "c-seed@2", "", "d-seed@3"}
+		if !reflect.DeepEqual(manifests, expected) {
+			t.Errorf("FetchManifests(%d) returned %v, expected %v", c.concurrency, manifests, expected)
+		}
+		for i, err := range errs {
+			if (err != nil) != (expected[i] == "") {
+				t.Errorf("FetchManifests(%d) returned error %v for %v", c.concurrency, err, images[i])
+			}
+		}
+		if !strings.Contains(errs[2].Error(), "Invalid seed name: bad") {
+			t.Errorf("FetchManifests(%d) returned %v for an invalid name", c.concurrency, errs[2])
+		}
+		if maxInFlight > c.maxInFlight {
+			t.Errorf("FetchManifests(%d) ran %d fetches at once, expected at most %d", c.concurrency, maxInFlight, c.maxInFlight)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, errs := FetchManifests(ctx, images, 1, func(ctx context.Context, repoName, tag string) (string, error) {
+		cancel()
+		return "", ctx.Err()
+	})
+	for i, err := range errs {
+		if err != context.Canceled {
+			t.Errorf("FetchManifests with a cancelled context returned %v for %v", err, images[i])
+		}
+	}
+
+	imageErrs := ImageErrors{{"a-seed:1", errors.New("boom")}, {"b-seed:1", errors.New("bang")}}
+	expected := "ERROR: Unable to read the manifests of 2 images:\na-seed:1: boom\nb-seed:1: bang"
+	if imageErrs.Error() != expected {
+		t.Errorf("ImageErrors.Error() returned %q, expected %q", imageErrs.Error(), expected)
+	}
+}
@@ -1,13 +1,15 @@
 package containeryard
 
 import (
+	"context"
 	"encoding/json"
-	"net/http"
+
+	"github.com/ngageoint/seed-common/registry/client"
 )
 
 // getContainerYardJson works with the list of repositories returned by container yard
-func (registry *ContainerYardRegistry) getContainerYardJson(url string, response interface{}) error {
-	resp, err := http.Get(url)
+func (registry *ContainerYardRegistry) getContainerYardJson(ctx context.Context, url string, response interface{}) error {
+	resp, err := client.Do(ctx, registry.Client, "GET", url, nil)
 	if err != nil {
 		return err
 	}
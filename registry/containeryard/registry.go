@@ -1,12 +1,14 @@
 package containeryard
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os"
 	"strings"
 
 	"github.com/heroku/docker-registry-client/registry"
+	"github.com/ngageoint/seed-common/registry/client"
 	"github.com/ngageoint/seed-common/util"
 )
 
@@ -20,6 +22,10 @@ type ContainerYardRegistry struct {
 	Password string
 	v2Base   *registry.Registry
 	Print    util.PrintCallback
+	//Cache holds the manifests read by GetImageManifest; nil disables caching
+	Cache *client.ManifestCache
+	//Platform is the platform whose image is read from multi-platform images; defaults to linux/amd64
+	Platform client.Platform
 }
 
 func (r *ContainerYardRegistry) Name() string {
@@ -28,11 +34,17 @@ func (r *ContainerYardRegistry) Name() string {
 
 //New creates a new docker hub registry from the given URL
 func New(registryUrl, org, username, password string) (*ContainerYardRegistry, error) {
+	return NewContext(context.Background(), registryUrl, org, username, password)
+}
+
+//NewContext creates a new container yard registry from the given URL, pinging its v2 registry
+// with the given context
+func NewContext(ctx context.Context, registryUrl, org, username, password string) (*ContainerYardRegistry, error) {
 	if util.PrintUtil == nil {
 		util.InitPrinter(util.PrintErr, os.Stderr, os.Stdout)
 	}
 	url := strings.TrimSuffix(registryUrl, "/")
-	reg, err := registry.New(url, username, password)
+	reg, err := client.New(ctx, url, username, password)
 
 	host := strings.Replace(url, "https://", "", 1)
 	host = strings.Replace(host, "http://", "", 1)
@@ -57,10 +69,24 @@ func (r *ContainerYardRegistry) url(pathTemplate string, args ...interface{}) st
 	return url
 }
 
+//SetCache sets the cache of the manifests read by GetImageManifest
+func (r *ContainerYardRegistry) SetCache(cache *client.ManifestCache) {
+	r.Cache = cache
+}
+
+//SetPlatform sets the platform whose image GetImageManifest reads from multi-platform images
+func (r *ContainerYardRegistry) SetPlatform(platform client.Platform) {
+	r.Platform = platform
+}
+
 func (r *ContainerYardRegistry) Ping() error {
+	return r.PingContext(context.Background())
+}
+
+func (r *ContainerYardRegistry) PingContext(ctx context.Context) error {
 	//query that should quickly return an empty json response
 	url := r.url("/search?q=NoImagesWithThisName&t=json")
 	var response Response
-	err := r.getContainerYardJson(url, &response)
+	err := r.getContainerYardJson(ctx, url, &response)
 	return err
 }
@@ -1,10 +1,11 @@
 package containeryard
 
 import (
-	"errors"
+	"context"
 	"strings"
 
 	"github.com/ngageoint/seed-common/objects"
+	"github.com/ngageoint/seed-common/registry/client"
 	"github.com/ngageoint/seed-common/util"
 )
 
@@ -41,12 +42,16 @@ type Result struct {
 }
 
 func (registry *ContainerYardRegistry) Repositories() ([]string, error) {
+	return registry.RepositoriesContext(context.Background())
+}
+
+func (registry *ContainerYardRegistry) RepositoriesContext(ctx context.Context) ([]string, error) {
 	url := registry.url("/search?q=%s&t=json", "-seed")
 	repos := make([]string, 0, 10)
 	var err error //We create this here, otherwise url will be rescoped with :=
 	var response Response
 
-	err = registry.getContainerYardJson(url, &response)
+	err = registry.getContainerYardJson(ctx, url, &response)
 	if err == nil {
 		for repoName := range response.Results.Community {
 			repos = append(repos, repoName)
@@ -60,13 +65,17 @@ func (registry *ContainerYardRegistry) Repositories() ([]string, error) {
 }
 
 func (registry *ContainerYardRegistry) Tags(repository string) ([]string, error) {
+	return registry.TagsContext(context.Background(), repository)
+}
+
+func (registry *ContainerYardRegistry) TagsContext(ctx context.Context, repository string) ([]string, error) {
 	url := registry.url("/search?q=%s&t=json", repository)
 	registry.Print("Searching %s for Seed images...\n", url)
 	tags := make([]string, 0, 10)
 	var err error //We create this here, otherwise url will be rescoped with :=
 	var response Response
 
-	err = registry.getContainerYardJson(url, &response)
+	err = registry.getContainerYardJson(ctx, url, &response)
 	if err == nil {
 		for _, image := range response.Results.Community {
 			for tagName := range image.Tags {
@@ -79,12 +88,18 @@ func (registry *ContainerYardRegistry) Tags(repository string) ([]string, error)
 			}
 		}
 	}
+	util.SortVersions(tags)
 	return tags, err
 }
 
 //Images returns all seed images on the registry
 func (registry *ContainerYardRegistry) Images() ([]string, error) {
-	images, err := registry.ImagesWithManifests()
+	return registry.ImagesContext(context.Background())
+}
+
+//ImagesContext returns all seed images on the registry using the given context
+func (registry *ContainerYardRegistry) ImagesContext(ctx context.Context) ([]string, error) {
+	images, err := registry.ImagesWithManifestsContext(ctx)
 	imageStrs := []string{}
 	for _, img := range images {
 		imageStrs = append(imageStrs, img.Name)
@@ -94,31 +109,39 @@ func (registry *ContainerYardRegistry) Images() ([]string, error) {
 
 //Images returns all seed images on the registry along with their manifests, if available
 func (registry *ContainerYardRegistry) ImagesWithManifests() ([]objects.Image, error) {
+	return registry.ImagesWithManifestsContext(context.Background())
+}
+
+//ImagesWithManifestsContext returns all seed images on the registry along with their manifests using
+// the given context
+func (registry *ContainerYardRegistry) ImagesWithManifestsContext(ctx context.Context) ([]objects.Image, error) {
 	//TODO: Update after container yard generates unique manifests for each tag
 	url := registry.url("/search?q=%s&t=json", "-seed")
 	repos := make([]objects.Image, 0, 10)
 	var err error //We create this here, otherwise url will be rescoped with :=
 	var response Response
 
-	err = registry.getContainerYardJson(url, &response)
+	err = registry.getContainerYardJson(ctx, url, &response)
 	if err == nil {
 		for repoName, image := range response.Results.Community {
 			if !strings.HasPrefix(repoName, registry.Org) {
 				registry.Print("Skipping image %s because it does not belong to org %s", repoName, registry.Org)
 				continue
 			}
-			manifestLabel := ""
-			for name, value := range image.Labels {
-				if name == "com.ngageoint.seed.manifest" {
-					manifestLabel = util.UnescapeManifestLabel(value)
-				}
+			manifestLabel, _, decodeErr := util.ManifestFromLabels(image.Labels)
+			if decodeErr != nil {
+				registry.Print("ERROR: Error decoding manifest label for %s: %s\n Skipping.\n", repoName, decodeErr.Error())
+				continue
 			}
 			if manifestLabel == "" {
 				registry.Print("Skipping image %s due to missing manifest label", repoName)
 				continue
 			}
 			for tagName := range image.Tags {
-				manifestLabel, err = registry.GetImageManifest(repoName, tagName)
+				manifestLabel, err = registry.GetImageManifestContext(ctx, repoName, tagName)
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
 				if err != nil {
 					//skip images with empty manifests
 					registry.Print("ERROR: Error reading v2 manifest for %s: %s\n Skipping.\n", repoName, err.Error())
@@ -142,18 +165,20 @@ func (registry *ContainerYardRegistry) ImagesWithManifests() ([]objects.Image, e
 				registry.Print("Skipping image %s because it does not belong to org %s", repoName, registry.Org)
 				continue
 			}
-			manifestLabel := ""
-			for name, value := range image.Labels {
-				if name == "com.ngageoint.seed.manifest" {
-					manifestLabel = util.UnescapeManifestLabel(value)
-				}
+			manifestLabel, _, decodeErr := util.ManifestFromLabels(image.Labels)
+			if decodeErr != nil {
+				registry.Print("ERROR: Error decoding manifest label for %s: %s\n Skipping.\n", repoName, decodeErr.Error())
+				continue
 			}
 			if manifestLabel == "" {
 				registry.Print("Skipping image %s due to missing manifest label", repoName)
 				continue
 			}
 			for tagName := range image.Tags {
-				manifestLabel, err = registry.GetImageManifest(repoName, tagName)
+				manifestLabel, err = registry.GetImageManifestContext(ctx, repoName, tagName)
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
 				if err != nil {
 					//skip images with empty manifests
 					registry.Print("ERROR: Error reading v2 manifest for %s: %s\n Skipping.\n", repoName, err.Error())
@@ -173,31 +198,21 @@ func (registry *ContainerYardRegistry) ImagesWithManifests() ([]objects.Image, e
 			}
 		}
 	}
-	return repos, nil
+	return repos, ctx.Err()
 }
 
 func (registry *ContainerYardRegistry) GetImageManifest(repoName, tag string) (string, error) {
-	manifest := ""
-	mv2, err := registry.v2Base.ManifestV2(repoName, tag)
-	if err == nil {
-		resp, err := registry.v2Base.DownloadLayer(repoName, mv2.Config.Digest)
-		if err == nil {
-			manifest, err = objects.GetSeedManifestFromBlob(resp)
-		}
-	}
-
-	if err == nil && manifest == "" {
-		err = errors.New("Empty seed manifest!")
-	}
+	return registry.GetImageManifestContext(context.Background(), repoName, tag)
+}
 
-	return manifest, err
+func (registry *ContainerYardRegistry) GetImageManifestContext(ctx context.Context, repoName, tag string) (string, error) {
+	return client.SeedManifest(ctx, registry.v2Base, registry.Cache, registry.Platform, repoName, tag)
 }
 
 func (registry *ContainerYardRegistry) RemoveImage(repoName, tag string) error {
-	digest, err := registry.v2Base.ManifestDigestV2(repoName, tag)
-	if err == nil {
-		err = registry.v2Base.DeleteManifest(repoName, digest)
-	}
+	return registry.RemoveImageContext(context.Background(), repoName, tag)
+}
 
-	return err
-}
\ No newline at end of file
+func (registry *ContainerYardRegistry) RemoveImageContext(ctx context.Context, repoName, tag string) error {
+	return client.RemoveImage(ctx, registry.v2Base, registry.Cache, repoName, tag)
+}
@@ -1,9 +1,11 @@
 package dockerhub
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
-	"net/http"
+
+	"github.com/ngageoint/seed-common/registry/client"
 )
 
 var (
@@ -15,8 +17,8 @@ var (
 // returned by docker hub. accepts a string and a pointer, and returns the
 // next page URL while updating pointed-to variable with a parsed JSON
 // value. When there are no more pages it returns `ErrNoMorePages`.
-func (registry *DockerHubRegistry) getDockerHubPaginatedJson(url string, response interface{}) (string, error) {
-	resp, err := http.Get(url)
+func (registry *DockerHubRegistry) getDockerHubPaginatedJson(ctx context.Context, url string, response interface{}) (string, error) {
+	resp, err := client.Do(ctx, registry.Client, "GET", url, nil)
 	if err != nil {
 		return "", err
 	}
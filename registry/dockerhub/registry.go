@@ -1,14 +1,17 @@
 package dockerhub
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/heroku/docker-registry-client/registry"
 	"github.com/ngageoint/seed-common/constants"
+	"github.com/ngageoint/seed-common/registry/client"
 	"github.com/ngageoint/seed-common/util"
 )
 
@@ -19,16 +22,31 @@ type DockerHubRegistry struct {
 	Org    string
 	v2Base *registry.Registry
 	Print  util.PrintCallback
+	//Concurrency is the number of manifests ImagesWithManifests fetches at once
+	Concurrency int
+	//Cache holds the manifests read by GetImageManifest; nil disables caching
+	Cache *client.ManifestCache
+	//Platform is the platform whose image is read from multi-platform images; defaults to linux/amd64
+	Platform client.Platform
+
+	imageErrs client.ImageErrors
+	mutex     sync.Mutex
 }
 
 //New creates a new docker hub registry from the given URL
 func New(registryUrl, org, username, password string) (*DockerHubRegistry, error) {
+	return NewContext(context.Background(), registryUrl, org, username, password)
+}
+
+//NewContext creates a new docker hub registry from the given URL, connecting to the docker hub
+// v2 registry with the given context
+func NewContext(ctx context.Context, registryUrl, org, username, password string) (*DockerHubRegistry, error) {
 	if util.PrintUtil == nil {
 		util.InitPrinter(util.PrintErr, os.Stderr, os.Stdout)
 	}
 	url := strings.TrimSuffix(registryUrl, "/")
 
-	reg, _ := registry.New("https://registry-1.docker.io/", username, password)
+	reg, _ := client.New(ctx, "https://registry-1.docker.io/", username, password)
 
 	registry := &DockerHubRegistry{
 		URL:    url,
@@ -51,9 +69,28 @@ func (r *DockerHubRegistry) Name() string {
 	return "DockerHubRegistry"
 }
 
+//SetConcurrency sets the number of manifests ImagesWithManifests fetches at once
+func (r *DockerHubRegistry) SetConcurrency(concurrency int) {
+	r.Concurrency = concurrency
+}
+
+//SetCache sets the cache of the manifests read by GetImageManifest
+func (r *DockerHubRegistry) SetCache(cache *client.ManifestCache) {
+	r.Cache = cache
+}
+
+//SetPlatform sets the platform whose image GetImageManifest reads from multi-platform images
+func (r *DockerHubRegistry) SetPlatform(platform client.Platform) {
+	r.Platform = platform
+}
+
 func (r *DockerHubRegistry) Ping() error {
+	return r.PingContext(context.Background())
+}
+
+func (r *DockerHubRegistry) PingContext(ctx context.Context) error {
 	url := r.url("/v2/repositories/%s/", constants.DefaultOrg)
-	resp, err := r.Client.Get(url)
+	resp, err := client.Do(ctx, r.Client, "GET", url, nil)
 	if resp != nil {
 		defer resp.Body.Close()
 		if resp.StatusCode != 200 {
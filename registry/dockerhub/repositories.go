@@ -1,10 +1,12 @@
 package dockerhub
 
 import (
-    "errors"
+	"context"
 	"strings"
 
 	"github.com/ngageoint/seed-common/objects"
+	"github.com/ngageoint/seed-common/registry/client"
+	"github.com/ngageoint/seed-common/util"
 )
 
 type repositoriesResponse struct {
@@ -21,6 +23,11 @@ type Result struct {
 
 //Repositories Returns seed repositories for the given user/organization
 func (registry *DockerHubRegistry) Repositories() ([]string, error) {
+	return registry.RepositoriesContext(context.Background())
+}
+
+//RepositoriesContext returns seed repositories for the given user/organization using the given context
+func (registry *DockerHubRegistry) RepositoriesContext(ctx context.Context) ([]string, error) {
 	user := registry.Org
 	url := registry.url("/v2/repositories/%s/", user)
 	repos := make([]string, 0, 10)
@@ -28,7 +35,7 @@ func (registry *DockerHubRegistry) Repositories() ([]string, error) {
 	var response repositoriesResponse
 	for err == nil {
 		response.Next = ""
-		url, err = registry.getDockerHubPaginatedJson(url, &response)
+		url, err = registry.getDockerHubPaginatedJson(ctx, url, &response)
 		for _, r := range response.Results {
 			if !strings.HasSuffix(r.Name, "-seed") {
 				continue
@@ -44,6 +51,11 @@ func (registry *DockerHubRegistry) Repositories() ([]string, error) {
 
 //Tags Returns tags for a given user/organization and repository
 func (registry *DockerHubRegistry) Tags(repository string) ([]string, error) {
+	return registry.TagsContext(context.Background(), repository)
+}
+
+//TagsContext returns tags for a given user/organization and repository using the given context
+func (registry *DockerHubRegistry) TagsContext(ctx context.Context, repository string) ([]string, error) {
 	user := registry.Org
 	url := registry.url("/v2/repositories/%s/%s/tags", user, repository)
 	tags := make([]string, 0, 10)
@@ -51,7 +63,7 @@ func (registry *DockerHubRegistry) Tags(repository string) ([]string, error) {
 	var response repositoriesResponse
 	for err == nil {
 		response.Next = ""
-		url, err = registry.getDockerHubPaginatedJson(url, &response)
+		url, err = registry.getDockerHubPaginatedJson(ctx, url, &response)
 		for _, r := range response.Results {
 			tags = append(tags, r.Name)
 		}
@@ -59,12 +71,18 @@ func (registry *DockerHubRegistry) Tags(repository string) ([]string, error) {
 	if err != ErrNoMorePages {
 		return nil, err
 	}
+	util.SortVersions(tags)
 	return tags, nil
 }
 
 //Images returns seed images for a given user/repository.  It will grab all of the seed repositories and combine them
 //with any tags it can find to build a list of images.
 func (registry *DockerHubRegistry) Images() ([]string, error) {
+	return registry.ImagesContext(context.Background())
+}
+
+//ImagesContext returns seed images for a given user/repository using the given context
+func (registry *DockerHubRegistry) ImagesContext(ctx context.Context) ([]string, error) {
 	url := registry.url("/v2/repositories/%s/", registry.Org)
 	registry.Print("Searching %s for Seed images...\n", url)
 	repos := make([]string, 0, 10)
@@ -72,18 +90,20 @@ func (registry *DockerHubRegistry) Images() ([]string, error) {
 	var response repositoriesResponse
 	for err == nil {
 		response.Next = ""
-		url, err = registry.getDockerHubPaginatedJson(url, &response)
+		url, err = registry.getDockerHubPaginatedJson(ctx, url, &response)
 		for _, r := range response.Results {
 			if !strings.HasSuffix(r.Name, "-seed") {
 				continue
 			}
 			// Add all tags if found
-			if rs, _ := registry.Tags(r.Name); len(rs) > 0 {
+			if rs, _ := registry.TagsContext(ctx, r.Name); len(rs) > 0 {
 				for _, tag := range rs {
 					img := r.Name + ":" + tag
 					repos = append(repos, img)
 				}
 				// No tags found - so just add the repo name
+			} else if ctx.Err() != nil {
+				return nil, ctx.Err()
 			} else {
 				repos = append(repos, r.Name)
 			}
@@ -96,56 +116,66 @@ func (registry *DockerHubRegistry) Images() ([]string, error) {
 }
 
 func (registry *DockerHubRegistry) ImagesWithManifests() ([]objects.Image, error) {
-	imageNames, err := registry.Images()
+	return registry.ImagesWithManifestsContext(context.Background())
+}
+
+//ImagesWithManifestsContext returns the seed images of the organization with their manifests,
+// fetching up to Concurrency manifests at once. Images are returned in the order of Images; images
+// whose manifest could not be read are skipped and reported by ImageErrors.
+func (registry *DockerHubRegistry) ImagesWithManifestsContext(ctx context.Context) ([]objects.Image, error) {
+	imageNames, err := registry.ImagesContext(ctx)
 
 	if err != nil {
 		return nil, err
 	}
 
+	manifests, errs := client.FetchManifests(ctx, imageNames, registry.Concurrency, registry.GetImageManifestContext)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
 	images := []objects.Image{}
+	imageErrs := client.ImageErrors{}
 
 	url := "docker.io"
 
-	manifest := ""
-	for _, imgstr := range imageNames {
-		temp := strings.Split(imgstr, ":")
-		if len(temp) != 2 {
-			registry.Print("ERROR: Invalid seed name: %s. Unable to split into name/tag pair\n", imgstr)
+	for i, imgstr := range imageNames {
+		if errs[i] != nil {
+			registry.Print("ERROR: Error reading manifest for %s: %s\n Skipping.\n", imgstr, errs[i].Error())
+			imageErrs = append(imageErrs, client.ImageError{Image: imgstr, Err: errs[i]})
 			continue
 		}
-		manifest, err = registry.GetImageManifest(temp[0], temp[1])
 
-		imageStruct := objects.Image{Name: imgstr, Registry: url, Org: registry.Org, Manifest: manifest}
+		imageStruct := objects.Image{Name: imgstr, Registry: url, Org: registry.Org, Manifest: manifests[i]}
 		images = append(images, imageStruct)
 	}
 
-	return images, err
+	registry.mutex.Lock()
+	registry.imageErrs = imageErrs
+	registry.mutex.Unlock()
+
+	return images, nil
 }
 
-func (registry *DockerHubRegistry) GetImageManifest(repoName, tag string) (string, error) {
-	manifest := ""
-	orgRepoName := registry.Org + "/" + repoName
-	mv2, err := registry.v2Base.ManifestV2(orgRepoName, tag)
-	if err == nil {
-		resp, err := registry.v2Base.DownloadLayer(orgRepoName, mv2.Config.Digest)
-		if err == nil {
-			manifest, err = objects.GetSeedManifestFromBlob(resp)
-		}
-	}
+//ImageErrors returns the images whose manifest could not be read by the last ImagesWithManifests call
+func (registry *DockerHubRegistry) ImageErrors() client.ImageErrors {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	return registry.imageErrs
+}
 
-	if err == nil && manifest == "" {
-		err = errors.New("Empty seed manifest!")
-	}
+func (registry *DockerHubRegistry) GetImageManifest(repoName, tag string) (string, error) {
+	return registry.GetImageManifestContext(context.Background(), repoName, tag)
+}
 
-	return manifest, err
+func (registry *DockerHubRegistry) GetImageManifestContext(ctx context.Context, repoName, tag string) (string, error) {
+	return client.SeedManifest(ctx, registry.v2Base, registry.Cache, registry.Platform, registry.Org+"/"+repoName, tag)
 }
 
 func (registry *DockerHubRegistry) RemoveImage(repoName, tag string) error {
-	orgRepoName := registry.Org + "/" + repoName
-	digest, err := registry.v2Base.ManifestDigestV2(orgRepoName, tag)
-	if err == nil {
-		err = registry.v2Base.DeleteManifest(orgRepoName, digest)
-	}
+	return registry.RemoveImageContext(context.Background(), repoName, tag)
+}
 
-	return err
-}
\ No newline at end of file
+func (registry *DockerHubRegistry) RemoveImageContext(ctx context.Context, repoName, tag string) error {
+	return client.RemoveImage(ctx, registry.v2Base, registry.Cache, registry.Org+"/"+repoName, tag)
+}
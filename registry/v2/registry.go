@@ -1,12 +1,14 @@
 package v2
 
 import (
-	"errors"
+	"context"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/heroku/docker-registry-client/registry"
 	"github.com/ngageoint/seed-common/objects"
+	"github.com/ngageoint/seed-common/registry/client"
 	"github.com/ngageoint/seed-common/util"
 )
 
@@ -17,14 +19,28 @@ type v2registry struct {
 	Username string
 	Password string
 	Print    util.PrintCallback
+	//Concurrency is the number of manifests ImagesWithManifests fetches at once
+	Concurrency int
+	//Cache holds the manifests read by GetImageManifest; nil disables caching
+	Cache *client.ManifestCache
+	//Platform is the platform whose image is read from multi-platform images; defaults to linux/amd64
+	Platform client.Platform
+
+	imageErrs client.ImageErrors
+	mutex     sync.Mutex
 }
 
 func New(url, org, username, password string) (*v2registry, error) {
+	return NewContext(context.Background(), url, org, username, password)
+}
+
+//NewContext creates a new v2 registry, pinging it with the given context
+func NewContext(ctx context.Context, url, org, username, password string) (*v2registry, error) {
 	if util.PrintUtil == nil {
 		util.InitPrinter(util.PrintErr, os.Stderr, os.Stdout)
 	}
 
-	reg, err := registry.New(url, username, password)
+	reg, err := client.New(ctx, url, username, password)
 	if reg != nil {
 		host := strings.Replace(url, "https://", "", 1)
 		host = strings.Replace(host, "http://", "", 1)
@@ -37,22 +53,47 @@ func (v2 *v2registry) Name() string {
 	return "V2"
 }
 
+//SetConcurrency sets the number of manifests ImagesWithManifests fetches at once
+func (v2 *v2registry) SetConcurrency(concurrency int) {
+	v2.Concurrency = concurrency
+}
+
+//SetCache sets the cache of the manifests read by GetImageManifest
+func (v2 *v2registry) SetCache(cache *client.ManifestCache) {
+	v2.Cache = cache
+}
+
+//SetPlatform sets the platform whose image GetImageManifest reads from multi-platform images
+func (v2 *v2registry) SetPlatform(platform client.Platform) {
+	v2.Platform = platform
+}
+
 func (v2 *v2registry) Ping() error {
-	err := v2.r.Ping()
-	return err
+	return v2.PingContext(context.Background())
+}
+
+func (v2 *v2registry) PingContext(ctx context.Context) error {
+	return client.Ping(ctx, v2.r)
 }
 
 func (v2 *v2registry) Repositories() ([]string, error) {
-	repositories, err := v2.r.Repositories()
+	return v2.RepositoriesContext(context.Background())
+}
+
+func (v2 *v2registry) RepositoriesContext(ctx context.Context) ([]string, error) {
+	repositories, err := client.Repositories(ctx, v2.r)
 	var repos []string
 	for _, repo := range repositories {
 		if !strings.HasSuffix(repo, "-seed") {
 			continue
 		}
-		if v2.Org != "" && !strings.HasPrefix(repo, v2.Org + "/") {
+		if v2.Org != "" && !strings.HasPrefix(repo, v2.Org+"/") {
 			continue
 		}
-		_, err2 := v2.Tags(repo)
+		_, err2 := v2.TagsContext(ctx, repo)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		if err2 != nil {
 			continue
 		}
@@ -62,23 +103,36 @@ func (v2 *v2registry) Repositories() ([]string, error) {
 }
 
 func (v2 *v2registry) Tags(repository string) ([]string, error) {
-	return v2.r.Tags(repository)
+	return v2.TagsContext(context.Background(), repository)
+}
+
+func (v2 *v2registry) TagsContext(ctx context.Context, repository string) ([]string, error) {
+	tags, err := client.Tags(ctx, v2.r, repository)
+	util.SortVersions(tags)
+	return tags, err
 }
 
 func (v2 *v2registry) Images() ([]string, error) {
+	return v2.ImagesContext(context.Background())
+}
+
+func (v2 *v2registry) ImagesContext(ctx context.Context) ([]string, error) {
 	url := v2.r.URL + "/v2/_catalog"
 	v2.Print("Searching %s for Seed images...\n", url)
-	repositories, err := v2.r.Repositories()
+	repositories, err := client.Repositories(ctx, v2.r)
 
 	var images []string
 	for _, repo := range repositories {
 		if !strings.HasSuffix(repo, "-seed") {
 			continue
 		}
-		if v2.Org != "" && !strings.HasPrefix(repo, v2.Org + "/") {
+		if v2.Org != "" && !strings.HasPrefix(repo, v2.Org+"/") {
 			continue
 		}
-		tags, err := v2.Tags(repo)
+		tags, err := v2.TagsContext(ctx, repo)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		if err != nil {
 			continue
 		}
@@ -91,7 +145,14 @@ func (v2 *v2registry) Images() ([]string, error) {
 }
 
 func (v2 *v2registry) ImagesWithManifests() ([]objects.Image, error) {
-	imageNames, err := v2.Images()
+	return v2.ImagesWithManifestsContext(context.Background())
+}
+
+//ImagesWithManifestsContext returns the seed images in the registry with their manifests, fetching
+// up to Concurrency manifests at once. Images are returned in the order of Images; images whose
+// manifest could not be read are skipped and reported by ImageErrors.
+func (v2 *v2registry) ImagesWithManifestsContext(ctx context.Context) ([]objects.Image, error) {
+	imageNames, err := v2.ImagesContext(ctx)
 	v2.Print("Images found in V2 Registry %s with Org %s: \n %v", v2.Hostname, v2.Org, imageNames)
 	v2.Print("Getting Manifests for %d images in V2 Registry %s with Org %s", len(imageNames), v2.Hostname, v2.Org)
 
@@ -99,19 +160,18 @@ func (v2 *v2registry) ImagesWithManifests() ([]objects.Image, error) {
 		return nil, err
 	}
 
-	images := []objects.Image{}
+	manifests, errs := client.FetchManifests(ctx, imageNames, v2.Concurrency, v2.GetImageManifestContext)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
 
-	for _, imgstr := range imageNames {
-		v2.Print("Getting manifest for %s", imgstr)
-		temp := strings.Split(imgstr, ":")
-		if len(temp) != 2 {
-			v2.Print("ERROR: Invalid seed name: %s. Unable to split into name/tag pair\n", imgstr)
-			continue
-		}
-		manifest, err := v2.GetImageManifest(temp[0], temp[1])
-		if err != nil {
+	images := []objects.Image{}
+	imageErrs := client.ImageErrors{}
+	for i, imgstr := range imageNames {
+		if errs[i] != nil {
 			//skip images with empty manifests
-			v2.Print("ERROR: Error reading v2 manifest for %s: %s\n Skipping.\n", imgstr, err.Error())
+			v2.Print("ERROR: Error reading v2 manifest for %s: %s\n Skipping.\n", imgstr, errs[i].Error())
+			imageErrs = append(imageErrs, client.ImageError{Image: imgstr, Err: errs[i]})
 			continue
 		}
 
@@ -122,36 +182,36 @@ func (v2 *v2registry) ImagesWithManifests() ([]objects.Image, error) {
 				imgOrg = imgstr[:index]
 			}
 		}
-		imageStruct := objects.Image{Name: imgstr, Registry: v2.Hostname, Org: imgOrg, Manifest: manifest}
+		imageStruct := objects.Image{Name: imgstr, Registry: v2.Hostname, Org: imgOrg, Manifest: manifests[i]}
 		images = append(images, imageStruct)
 	}
 
-	return images, err
+	v2.mutex.Lock()
+	v2.imageErrs = imageErrs
+	v2.mutex.Unlock()
+
+	return images, nil
 }
 
-func (v2 *v2registry) GetImageManifest(repoName, tag string) (string, error) {
-	manifest := ""
-	mv2, err := v2.r.ManifestV2(repoName, tag)
-	if err == nil {
-		resp, err := v2.r.DownloadLayer(repoName, mv2.Config.Digest)
-		if err == nil {
-			manifest, err = objects.GetSeedManifestFromBlob(resp)
-		}
-	}
+//ImageErrors returns the images whose manifest could not be read by the last ImagesWithManifests call
+func (v2 *v2registry) ImageErrors() client.ImageErrors {
+	v2.mutex.Lock()
+	defer v2.mutex.Unlock()
+	return v2.imageErrs
+}
 
-	if err == nil && manifest == "" {
-		err = errors.New("Empty seed manifest!")
-	}
+func (v2 *v2registry) GetImageManifest(repoName, tag string) (string, error) {
+	return v2.GetImageManifestContext(context.Background(), repoName, tag)
+}
 
-	return manifest, err
+func (v2 *v2registry) GetImageManifestContext(ctx context.Context, repoName, tag string) (string, error) {
+	return client.SeedManifest(ctx, v2.r, v2.Cache, v2.Platform, repoName, tag)
 }
 
 func (v2 *v2registry) RemoveImage(repoName, tag string) error {
-	digest, err := v2.r.ManifestDigestV2(repoName, tag)
-
-	if err == nil {
-		err = v2.r.DeleteManifest(repoName, digest)
-	}
+	return v2.RemoveImageContext(context.Background(), repoName, tag)
+}
 
-	return err
-}
\ No newline at end of file
+func (v2 *v2registry) RemoveImageContext(ctx context.Context, repoName, tag string) error {
+	return client.RemoveImage(ctx, v2.r, v2.Cache, repoName, tag)
+}
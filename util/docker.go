@@ -8,7 +8,6 @@ import (
 	"io/ioutil"
 	"os"
 	"os/exec"
-	"strconv"
 	"strings"
 	"time"
 
@@ -70,29 +69,17 @@ func DockerVersionGreaterThan(major, minor, patch int) bool {
 	// Print out any std out
 	slurp, _ := ioutil.ReadAll(outPipe)
 	if string(slurp) != "" {
-		version := strings.Split(string(slurp), ".")
-
-		// check each part of version. Return false if 1st < 1, 2nd < 11, 3rd < 1
-		if len(version) > 1 {
-			v1, _ := strconv.Atoi(version[0])
-			v2, _ := strconv.Atoi(version[1])
-
-			// check for minimum of 1.11.1
-			if v1 == major {
-				if v2 > minor {
-					return true
-				} else if v2 == minor && len(version) == 3 {
-					v3, _ := strconv.Atoi(version[2])
-					if v3 >= patch {
-						return true
-					}
-				}
-			} else if v1 > major {
-				return true
-			}
-
+		version, err := ParseLooseVersion(strings.Trim(string(slurp), "'\n "))
+		if err != nil {
+			PrintUtil("ERROR: Error parsing docker version. %s\n", err.Error())
 			return false
 		}
+
+		minimum := Version{Major: uint64(major), Minor: uint64(minor), Patch: uint64(patch)}
+
+		// ignore pre-release suffixes such as -ce so 17.06.0-ce satisfies 17.06.0
+		version.PreRelease = nil
+		return !version.LessThan(minimum)
 	}
 
 	return false
@@ -127,8 +114,10 @@ func ImageMemoryUsage(imageName string) {
 
 func Login(registry, username, password string) error {
 	var errs, out bytes.Buffer
-	args := []string{"login", "-u", username, "-p", password, registry}
+	defer RegisterSecret(password)()
+	args := []string{"login", "-u", username, "--password-stdin", registry}
 	cmd := exec.Command("docker", args...)
+	cmd.Stdin = strings.NewReader(password)
 	if StdErr != nil {
 		cmd.Stderr = io.MultiWriter(StdErr, &errs)
 	} else {
@@ -140,7 +129,7 @@ func Login(registry, username, password string) error {
 
 	errStr := strings.ToUpper(errs.String())
 	if strings.Contains(errStr, "WARNING") {
-		//report warnings but don't return error (i.e. --password via CLI is insecure warning)
+		//report warnings but don't return error (i.e. unencrypted credential storage warning)
 		PrintUtil("Docker login warning: %s\n", errs.String())
 	}
 
@@ -151,8 +140,7 @@ func Login(registry, username, password string) error {
 
 	if err != nil {
 		errMsg := fmt.Sprintf("ERROR: Error executing docker login.\n%s\n", err.Error())
-		errors.New(errMsg)
-		return err
+		return errors.New(errMsg)
 	}
 
 	PrintUtil("%s", out.String())
@@ -406,11 +394,10 @@ func DockerPull(image, registry, org, username, password string) (string, error)
 }
 
 func GetSeedManifestFromImage(imageName string) (string, error) {
-	cmdStr := "inspect -f '{{index .Config.Labels \"com.ngageoint.seed.manifest\"}}'" + imageName
+	cmdStr := "inspect -f '{{json .Config.Labels}}' " + imageName
 	PrintUtil("INFO: Retrieving seed manifest from %s LABEL=com.ngageoint.seed.manifest\n", imageName)
 
-	inspectCommand := exec.Command("docker", "inspect", "-f",
-		"'{{index .Config.Labels \"com.ngageoint.seed.manifest\"}}'", imageName)
+	inspectCommand := exec.Command("docker", "inspect", "-f", "'{{json .Config.Labels}}'", imageName)
 
 	errPipe, err := inspectCommand.StderrPipe()
 	if err != nil {
@@ -442,10 +429,10 @@ func GetSeedManifestFromImage(imageName string) (string, error) {
 			cmdStr, string(slurperr))
 	}
 
-	// un-escape special characters
-	label := string(seedBytes)
-
-	seedStr := UnescapeManifestLabel(label)
+	seedStr, _, decodeErr := ManifestFromInspectLabels(string(seedBytes))
+	if err == nil {
+		err = decodeErr
+	}
 
 	return seedStr, err
 }
@@ -101,7 +101,8 @@ func DockerfileBaseRegistry(dir string) (string, error) {
 	return registry, err
 }
 
-//GetSeedFileName Finds and returns the full filepath to the seed.manifest.json
+//GetSeedFileName Finds and returns the full filepath to the seed manifest. The JSON
+// seed.manifest.json is preferred, followed by seed.manifest.yaml and seed.manifest.yml.
 // The second return value indicates whether it exists or not.
 func GetSeedFileName(dir string) (string, bool, error) {
 	// Define the current working directory
@@ -110,23 +111,37 @@ func GetSeedFileName(dir string) (string, bool, error) {
 	// set path to seed file -
 	// 	Either relative to current directory or located in given directory
 	//  	-d directory might be a relative path to current directory
-	seedFileName := constants.SeedFileName
+	seedDir := dir
 	if dir == "." {
-		seedFileName = filepath.Join(curDirectory, seedFileName)
-	} else {
-		if filepath.IsAbs(dir) {
-			seedFileName = filepath.Join(dir, seedFileName)
-		} else {
-			seedFileName = filepath.Join(curDirectory, dir, seedFileName)
+		seedDir = curDirectory
+	} else if !filepath.IsAbs(dir) {
+		seedDir = filepath.Join(curDirectory, dir)
+	}
+
+	// Check to see if a seed manifest exists within specified directory.
+	var firstErr error
+	for _, name := range []string{constants.SeedFileName, constants.SeedYamlFileName, constants.SeedYmlFileName} {
+		seedFileName := filepath.Join(seedDir, name)
+		_, err := os.Stat(seedFileName)
+		if !os.IsNotExist(err) {
+			return seedFileName, true, err
+		}
+		if firstErr == nil {
+			firstErr = err
 		}
 	}
 
-	// Check to see if seed.manifest.json exists within specified directory.
-	_, err := os.Stat(seedFileName)
-	return seedFileName, !os.IsNotExist(err), err
+	return filepath.Join(seedDir, constants.SeedFileName), false, firstErr
+}
+
+//IsYamlManifest checks if the given seed manifest file is written in YAML
+func IsYamlManifest(seedFileName string) bool {
+	ext := strings.ToLower(filepath.Ext(seedFileName))
+	return ext == ".yaml" || ext == ".yml"
 }
 
-//SeedFileName Finds and returns the full filepath to the seed.manifest.json
+//SeedFileName Finds and returns the full filepath to the seed manifest found by GetSeedFileName
+// (seed.manifest.json, seed.manifest.yaml or seed.manifest.yml). An error is returned if none exists.
 func SeedFileName(dir string) (string, error) {
 	seedFileName, exists, err := GetSeedFileName(dir)
 	if !exists {
@@ -165,3 +180,25 @@ func ReadLinesFromFile(filename string) ([]string, error) {
 
 	return lines, nil
 }
+
+//TotalFileSizeMiB returns the combined size, in MiB, of the given files. Directories are walked
+// and the size of every file beneath them is included.
+func TotalFileSizeMiB(paths []string) (float64, error) {
+	var total int64
+	for _, path := range paths {
+		err := filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() {
+				total += info.Size()
+			}
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return float64(total) / (1024.0 * 1024.0), nil
+}
@@ -66,7 +66,7 @@ func TestReadLinesFromFile(t *testing.T) {
 		errorMsg  string
 	}{
 		{".", 0, "seed.manifest.json cannot be found"},
-		{"../testdata/complete", 101, ""},
+		{"../testdata/complete", 103, ""},
 	}
 
 	for _, c := range cases {
@@ -91,3 +91,53 @@ func TestReadLinesFromFile(t *testing.T) {
 		}
 	}
 }
+
+func TestTotalFileSizeMiB(t *testing.T) {
+	cases := []struct {
+		paths    []string
+		empty    bool
+		errorMsg string
+	}{
+		{[]string{}, true, ""},
+		{[]string{"../testdata/complete/seed.manifest.json", "../testdata/remote-base-registry"}, false, ""},
+		{[]string{"../testdata/missing"}, true, "no such file or directory"},
+	}
+
+	for _, c := range cases {
+		size, err := TotalFileSizeMiB(c.paths)
+		if (size == 0) != c.empty {
+			t.Errorf("TotalFileSizeMiB(%v) returned %v, expected empty: %v", c.paths, size, c.empty)
+		}
+
+		errMsg := ""
+		if err != nil {
+			errMsg = err.Error()
+		}
+		if !strings.Contains(errMsg, c.errorMsg) {
+			t.Errorf("TotalFileSizeMiB(%v) == %v, expected %v", c.paths, errMsg, c.errorMsg)
+		}
+	}
+}
+
+func TestGetSeedFileName(t *testing.T) {
+	cases := []struct {
+		dir    string
+		ending string
+		exists bool
+		yaml   bool
+	}{
+		{"../testdata/complete", "/testdata/complete/seed.manifest.json", true, false},
+		{"../testdata/yaml", "/testdata/yaml/seed.manifest.yaml", true, true},
+		{"../testdata/remote-base-registry", "/testdata/remote-base-registry/seed.manifest.json", false, false},
+	}
+
+	for _, c := range cases {
+		name, exists, _ := GetSeedFileName(c.dir)
+		if !strings.HasSuffix(name, c.ending) || exists != c.exists {
+			t.Errorf("GetSeedFileName(%q) == %v, %v, expected %v, %v", c.dir, name, exists, c.ending, c.exists)
+		}
+		if IsYamlManifest(name) != c.yaml {
+			t.Errorf("IsYamlManifest(%q) == %v, expected %v", name, !c.yaml, c.yaml)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package util
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/ngageoint/seed-common/constants"
+)
+
+//LabelEncoding identifies how a seed manifest was escaped when it was stored in the
+// com.ngageoint.seed.manifest image label
+type LabelEncoding int
+
+const (
+	//LabelEncodingNone means the label is empty
+	LabelEncodingNone LabelEncoding = iota
+
+	//LabelEncodingRaw means the label holds the manifest JSON as is. This is what docker stores
+	// when an encoded label is used as a quoted LABEL value in a Dockerfile.
+	LabelEncodingRaw
+
+	//LabelEncodingQuoted means the label holds the manifest as a JSON string literal, as returned by
+	// EncodeManifestLabel. This is what docker stores when the encoded label is passed to --label.
+	LabelEncodingQuoted
+
+	//LabelEncodingEscaped means the label holds the contents of a JSON string literal without the
+	// surrounding quotes, i.e. {\"seedVersion\":\"1.0.0\"...
+	LabelEncodingEscaped
+
+	//LabelEncodingDoubleEscaped means the manifest was escaped more than once, i.e. by a build
+	// script that escaped an already encoded label
+	LabelEncodingDoubleEscaped
+
+	//LabelEncodingCompressed means the manifest was gzip compressed and base64 encoded under the
+	// com.ngageoint.seed.manifest.gz label
+	LabelEncodingCompressed
+)
+
+//maxLabelEscaping limits how many levels of escaping DecodeManifestLabel will remove
+const maxLabelEscaping = 4
+
+//String returns the name of the label encoding
+func (e LabelEncoding) String() string {
+	switch e {
+	case LabelEncodingNone:
+		return "none"
+	case LabelEncodingRaw:
+		return "raw"
+	case LabelEncodingQuoted:
+		return "quoted"
+	case LabelEncodingEscaped:
+		return "escaped"
+	case LabelEncodingDoubleEscaped:
+		return "double-escaped"
+	case LabelEncodingCompressed:
+		return "compressed"
+	}
+	return fmt.Sprintf("unknown(%d)", int(e))
+}
+
+//EncodeManifestLabel encodes a seed manifest as the value of the com.ngageoint.seed.manifest label.
+// The manifest is compacted and quoted as a JSON string literal. Dollar signs are then escaped as \$
+// so docker does not expand them as build variables, and forward slashes are escaped as \/. The
+// result can be used as a quoted LABEL value in a Dockerfile or passed to docker build --label.
+// DecodeManifestLabel reverses either form.
+func EncodeManifestLabel(manifest []byte) (string, error) {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, manifest); err != nil {
+		return "", err
+	}
+
+	quoted, err := json.Marshal(compact.String())
+	if err != nil {
+		return "", err
+	}
+
+	label := strings.Replace(string(quoted), "$", "\\$", -1)
+	label = strings.Replace(label, "/", "\\/", -1)
+
+	return label, nil
+}
+
+//DecodeManifestLabel decodes the value of a com.ngageoint.seed.manifest label into the manifest
+// JSON and reports which encoding was used. Surrounding whitespace and the single quotes added by
+// docker inspect -f '...' are ignored. An empty label returns an empty manifest and LabelEncodingNone.
+func DecodeManifestLabel(label string) (string, LabelEncoding, error) {
+	value := strings.TrimSpace(label)
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		value = strings.TrimSpace(value[1 : len(value)-1])
+	}
+	if value == "" {
+		return "", LabelEncodingNone, nil
+	}
+
+	encoding := LabelEncodingRaw
+	for depth := 0; depth <= maxLabelEscaping; depth++ {
+		if value[0] == '{' && json.Valid([]byte(value)) {
+			return value, encoding, nil
+		}
+
+		next := LabelEncodingDoubleEscaped
+		if depth == 0 && value[0] == '"' {
+			next = LabelEncodingQuoted
+		} else if depth == 0 {
+			next = LabelEncodingEscaped
+		}
+
+		quoted := value
+		if quoted[0] != '"' {
+			quoted = "\"" + quoted + "\""
+		}
+		unquoted, err := unquoteLabel(quoted)
+		if err != nil {
+			msg := fmt.Sprintf("ERROR: Unable to decode seed manifest label. %s", err.Error())
+			return "", next, errors.New(msg)
+		}
+		unquoted = strings.TrimSpace(unquoted)
+		if unquoted == value {
+			// nothing was escaped, so this is not an escaped manifest
+			break
+		}
+		value, encoding = unquoted, next
+		if value == "" {
+			return "", encoding, nil
+		}
+	}
+
+	return "", encoding, errors.New("ERROR: Unable to decode seed manifest label. The label does not contain a JSON object")
+}
+
+//unquoteLabel decodes a quoted label value, allowing the \$ escape that JSON does not define
+func unquoteLabel(quoted string) (string, error) {
+	var buffer bytes.Buffer
+	for i := 0; i < len(quoted); i++ {
+		if quoted[i] == '\\' && i+1 < len(quoted) {
+			if quoted[i+1] == '$' {
+				buffer.WriteByte('$')
+			} else {
+				buffer.WriteByte(quoted[i])
+				buffer.WriteByte(quoted[i+1])
+			}
+			i++
+			continue
+		}
+		buffer.WriteByte(quoted[i])
+	}
+
+	var unquoted string
+	if err := json.Unmarshal(buffer.Bytes(), &unquoted); err != nil {
+		return "", err
+	}
+	return unquoted, nil
+}
+
+//EncodeCompressedManifestLabel encodes a seed manifest as the value of the
+// com.ngageoint.seed.manifest.gz label: the compacted manifest is gzip compressed and base64
+// encoded, which needs no escaping in a Dockerfile or on the command line
+func EncodeCompressedManifestLabel(manifest []byte) (string, error) {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, manifest); err != nil {
+		return "", err
+	}
+
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write(compact.Bytes()); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(compressed.Bytes()), nil
+}
+
+//DecodeCompressedManifestLabel decodes the value of a com.ngageoint.seed.manifest.gz label
+func DecodeCompressedManifestLabel(label string) (string, error) {
+	value := strings.Trim(label, "'\" \n")
+	if value == "" {
+		return "", nil
+	}
+
+	compressed, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		msg := fmt.Sprintf("ERROR: Unable to decode compressed seed manifest label. %s", err.Error())
+		return "", errors.New(msg)
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		msg := fmt.Sprintf("ERROR: Unable to decompress seed manifest label. %s", err.Error())
+		return "", errors.New(msg)
+	}
+	defer reader.Close()
+	manifest, err := ioutil.ReadAll(reader)
+	if err != nil {
+		msg := fmt.Sprintf("ERROR: Unable to decompress seed manifest label. %s", err.Error())
+		return "", errors.New(msg)
+	}
+	if !json.Valid(manifest) {
+		return "", errors.New("ERROR: Compressed seed manifest label does not contain valid JSON")
+	}
+
+	return string(manifest), nil
+}
+
+//ManifestFromLabels returns the seed manifest stored in the given image labels. The plain
+// com.ngageoint.seed.manifest label is used when present, otherwise the compressed
+// com.ngageoint.seed.manifest.gz label is decoded. An empty manifest is returned if the image has
+// neither label.
+func ManifestFromLabels(labels map[string]string) (string, LabelEncoding, error) {
+	manifest, encoding, err := DecodeManifestLabel(labels[constants.SeedManifestLabel])
+	if err != nil || manifest != "" {
+		return manifest, encoding, err
+	}
+
+	compressed, ok := labels[constants.SeedCompressedManifestLabel]
+	if !ok || strings.TrimSpace(compressed) == "" {
+		return "", LabelEncodingNone, nil
+	}
+	manifest, err = DecodeCompressedManifestLabel(compressed)
+	return manifest, LabelEncodingCompressed, err
+}
+
+//ManifestFromInspectLabels returns the seed manifest from the output of
+// docker inspect -f '{{json .Config.Labels}}'
+func ManifestFromInspectLabels(output string) (string, LabelEncoding, error) {
+	value := strings.Trim(output, "' \n")
+	labels := map[string]string{}
+	if value != "" && value != "null" {
+		if err := json.Unmarshal([]byte(value), &labels); err != nil {
+			msg := fmt.Sprintf("ERROR: Unable to parse image labels. %s", err.Error())
+			return "", LabelEncodingNone, errors.New(msg)
+		}
+	}
+	return ManifestFromLabels(labels)
+}
@@ -0,0 +1,174 @@
+package util
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//dockerfileUnquote emulates how docker processes a double quoted Dockerfile LABEL value
+func dockerfileUnquote(value string) string {
+	value = value[1 : len(value)-1]
+	result := []byte{}
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+1 < len(value) && strings.ContainsRune("\"$\\", rune(value[i+1])) {
+			i++
+		}
+		result = append(result, value[i])
+	}
+	return string(result)
+}
+
+func equalJSON(a, b string) bool {
+	var aValue, bValue interface{}
+	if json.Unmarshal([]byte(a), &aValue) != nil || json.Unmarshal([]byte(b), &bValue) != nil {
+		return false
+	}
+	return reflect.DeepEqual(aValue, bValue)
+}
+
+func TestManifestLabelRoundTrip(t *testing.T) {
+	cases := []string{
+		`{}`,
+		`{"seedVersion":"1.0.0"}`,
+		`{"job":{"command":"${INPUT_FILE} $OUTPUT_DIR","title":"Ünïcödé 地図 ✓"}}`,
+		`{"job":{"description":"back\\slash \"quoted\" C:\\\\path\\$HOME"}}`,
+		`{"job":{"command":"/app/run.sh \\/ '$1' </dev/null && echo \u2028"}}`,
+	}
+
+	for _, manifest := range cases {
+		label, err := EncodeManifestLabel([]byte(manifest))
+		if err != nil {
+			t.Errorf("EncodeManifestLabel(%q) returned an error: %v", manifest, err)
+			continue
+		}
+
+		escaped := LabelEncodingEscaped
+		if !strings.Contains(label, "\\") {
+			// nothing needed escaping, so the unquoted label is the raw manifest
+			escaped = LabelEncodingRaw
+		}
+
+		variants := []struct {
+			label    string
+			encoding LabelEncoding
+		}{
+			{label, LabelEncodingQuoted},
+			{"'" + label + "'\n", LabelEncodingQuoted},
+			{label[1 : len(label)-1], escaped},
+			{dockerfileUnquote(label), LabelEncodingRaw},
+		}
+		for _, v := range variants {
+			decoded, encoding, err := DecodeManifestLabel(v.label)
+			if err != nil {
+				t.Errorf("DecodeManifestLabel(%q) returned an error: %v", v.label, err)
+				continue
+			}
+			if !equalJSON(decoded, manifest) {
+				t.Errorf("DecodeManifestLabel(%q) returned %s, expected %s", v.label, decoded, manifest)
+			}
+			if encoding != v.encoding {
+				t.Errorf("DecodeManifestLabel(%q) detected %v encoding, expected %v", v.label, encoding, v.encoding)
+			}
+		}
+	}
+}
+
+func TestDecodeManifestLabel(t *testing.T) {
+	cases := []struct {
+		label    string
+		manifest string
+		encoding LabelEncoding
+		errStr   string
+	}{
+		{"", "", LabelEncodingNone, ""},
+		{"  ''\n", "", LabelEncodingNone, ""},
+		{`""`, "", LabelEncodingQuoted, ""},
+		{`{"a":"b\/c"}`, `{"a":"b\/c"}`, LabelEncodingRaw, ""},
+		{`"{\"a\":\"\$b\"}"`, `{"a":"$b"}`, LabelEncodingQuoted, ""},
+		{`"\"{\\\"a\\\":1}\""`, `{"a":1}`, LabelEncodingDoubleEscaped, ""},
+		{`{\\\"a\\\":1}`, `{"a":1}`, LabelEncodingDoubleEscaped, ""},
+		{`"`, "", LabelEncodingQuoted, "Unable to decode seed manifest label"},
+		{`"[1, 2]"`, "", LabelEncodingQuoted, "Unable to decode seed manifest label"},
+		{`{"a":`, "", LabelEncodingEscaped, "Unable to decode seed manifest label"},
+		{"not json", "", LabelEncodingRaw, "Unable to decode seed manifest label"},
+	}
+
+	for _, c := range cases {
+		manifest, encoding, err := DecodeManifestLabel(c.label)
+		if manifest != c.manifest || encoding != c.encoding {
+			t.Errorf("DecodeManifestLabel(%q) returned %q, %v, expected %q, %v", c.label, manifest, encoding, c.manifest, c.encoding)
+		}
+		if err == nil && c.errStr != "" {
+			t.Errorf("DecodeManifestLabel(%q) did not return an error when one was expected: %v", c.label, c.errStr)
+		}
+		if err != nil && (c.errStr == "" || !strings.Contains(err.Error(), c.errStr)) {
+			t.Errorf("DecodeManifestLabel(%q) returned an error: %v\n expected %v", c.label, err, c.errStr)
+		}
+	}
+}
+
+func TestCompressedManifestLabel(t *testing.T) {
+	manifest := `{"job": {"name": "big", "description": "` + strings.Repeat("long description $HOME/\\\" ", 200) + `"}}`
+	label, err := EncodeCompressedManifestLabel([]byte(manifest))
+	if err != nil {
+		t.Fatalf("EncodeCompressedManifestLabel returned an error: %v", err)
+	}
+	plain, _ := EncodeManifestLabel([]byte(manifest))
+	if len(label) >= len(plain) || strings.ContainsAny(label, "\"$\\ ") {
+		t.Errorf("EncodeCompressedManifestLabel returned %d unescaped bytes, expected fewer than the %d byte plain label", len(label), len(plain))
+	}
+
+	decoded, err := DecodeCompressedManifestLabel("'" + label + "'\n")
+	if err != nil || !equalJSON(decoded, manifest) {
+		t.Errorf("DecodeCompressedManifestLabel returned %v, %v, expected %v", decoded, err, manifest)
+	}
+
+	if _, err = EncodeCompressedManifestLabel([]byte("{")); err == nil {
+		t.Errorf("EncodeCompressedManifestLabel did not return an error for invalid JSON")
+	}
+}
+
+func TestManifestFromLabels(t *testing.T) {
+	manifest := `{"seedVersion":"1.0.0"}`
+	plain, _ := EncodeManifestLabel([]byte(manifest))
+	compressed, _ := EncodeCompressedManifestLabel([]byte(manifest))
+
+	cases := []struct {
+		labels   map[string]string
+		manifest string
+		encoding LabelEncoding
+		errStr   string
+	}{
+		{nil, "", LabelEncodingNone, ""},
+		{map[string]string{"other": "value"}, "", LabelEncodingNone, ""},
+		{map[string]string{"com.ngageoint.seed.manifest": plain}, manifest, LabelEncodingQuoted, ""},
+		{map[string]string{"com.ngageoint.seed.manifest.gz": compressed}, manifest, LabelEncodingCompressed, ""},
+		{map[string]string{"com.ngageoint.seed.manifest": manifest, "com.ngageoint.seed.manifest.gz": "bad"}, manifest, LabelEncodingRaw, ""},
+		{map[string]string{"com.ngageoint.seed.manifest": "", "com.ngageoint.seed.manifest.gz": compressed}, manifest, LabelEncodingCompressed, ""},
+		{map[string]string{"com.ngageoint.seed.manifest.gz": "not base64!"}, "", LabelEncodingCompressed, "Unable to decode compressed seed manifest label"},
+		{map[string]string{"com.ngageoint.seed.manifest.gz": "bm90IGd6aXA="}, "", LabelEncodingCompressed, "Unable to decompress seed manifest label"},
+	}
+
+	for _, c := range cases {
+		manifest, encoding, err := ManifestFromLabels(c.labels)
+		if manifest != c.manifest || encoding != c.encoding {
+			t.Errorf("ManifestFromLabels(%v) returned %q, %v, expected %q, %v", c.labels, manifest, encoding, c.manifest, c.encoding)
+		}
+		if err == nil && c.errStr != "" {
+			t.Errorf("ManifestFromLabels(%v) did not return an error when one was expected: %v", c.labels, c.errStr)
+		}
+		if err != nil && (c.errStr == "" || !strings.Contains(err.Error(), c.errStr)) {
+			t.Errorf("ManifestFromLabels(%v) returned an error: %v\n expected %v", c.labels, err, c.errStr)
+		}
+	}
+
+	inspect := `'{"com.ngageoint.seed.manifest.gz":"` + compressed + `"}'` + "\n"
+	if decoded, encoding, err := ManifestFromInspectLabels(inspect); decoded != manifest || encoding != LabelEncodingCompressed || err != nil {
+		t.Errorf("ManifestFromInspectLabels(%q) returned %v, %v, %v, expected %v", inspect, decoded, encoding, err, manifest)
+	}
+	if decoded, _, err := ManifestFromInspectLabels("'null'\n"); decoded != "" || err != nil {
+		t.Errorf("ManifestFromInspectLabels returned %v, %v for an image without labels", decoded, err)
+	}
+}
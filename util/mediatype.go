@@ -0,0 +1,171 @@
+package util
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+//DefaultMediaType is returned when the media type of a file cannot be determined
+const DefaultMediaType = "application/octet-stream"
+
+//sniffLength is the number of bytes read from the start of a file to detect its media type
+const sniffLength = 512
+
+//mediaSignature identifies a media type by the magic bytes at an offset in the file
+type mediaSignature struct {
+	mediaType string
+	offset    int
+	magic     []byte
+}
+
+//MediaTypeRegistry detects the media type of files from their magic bytes and extensions.
+// Signatures and extensions registered later take precedence over earlier ones so that built in
+// types can be overridden.
+type MediaTypeRegistry struct {
+	mutex      sync.RWMutex
+	signatures []mediaSignature
+	extensions map[string]string
+}
+
+//NewMediaTypeRegistry returns a registry of the common media types used by seed jobs
+func NewMediaTypeRegistry() *MediaTypeRegistry {
+	r := &MediaTypeRegistry{extensions: make(map[string]string)}
+
+	r.RegisterMagic("image/x-hdf5-image", 0, []byte("\x89HDF\r\n\x1a\n"))
+	r.RegisterMagic("image/tiff", 0, []byte("II*\x00"))
+	r.RegisterMagic("image/tiff", 0, []byte("MM\x00*"))
+	r.RegisterMagic("image/tiff", 0, []byte("II+\x00")) // BigTIFF
+	r.RegisterMagic("image/tiff", 0, []byte("MM\x00+"))
+	r.RegisterMagic("image/png", 0, []byte("\x89PNG\r\n\x1a\n"))
+	r.RegisterMagic("image/jpeg", 0, []byte("\xff\xd8\xff"))
+	r.RegisterMagic("image/gif", 0, []byte("GIF87a"))
+	r.RegisterMagic("image/gif", 0, []byte("GIF89a"))
+	r.RegisterMagic("image/jp2", 0, []byte("\x00\x00\x00\x0cjP  \r\n\x87\n"))
+	r.RegisterMagic("application/vnd.nitf", 0, []byte("NITF"))
+	r.RegisterMagic("application/vnd.nitf", 0, []byte("NSIF"))
+	r.RegisterMagic("application/pdf", 0, []byte("%PDF-"))
+	r.RegisterMagic("application/zip", 0, []byte("PK\x03\x04"))
+	r.RegisterMagic("application/gzip", 0, []byte("\x1f\x8b"))
+	r.RegisterMagic("application/x-netcdf", 0, []byte("CDF\x01"))
+	r.RegisterMagic("application/x-netcdf", 0, []byte("CDF\x02"))
+
+	r.RegisterExtension("image/x-hdf5-image", ".h5", ".hdf5", ".he5")
+	r.RegisterExtension("image/tiff", ".tif", ".tiff")
+	r.RegisterExtension("image/png", ".png")
+	r.RegisterExtension("image/jpeg", ".jpg", ".jpeg")
+	r.RegisterExtension("image/gif", ".gif")
+	r.RegisterExtension("image/jp2", ".jp2")
+	r.RegisterExtension("application/vnd.nitf", ".ntf", ".nitf")
+	r.RegisterExtension("application/pdf", ".pdf")
+	r.RegisterExtension("application/zip", ".zip")
+	r.RegisterExtension("application/gzip", ".gz")
+	r.RegisterExtension("application/x-netcdf", ".nc")
+	r.RegisterExtension("application/json", ".json")
+	r.RegisterExtension("application/geo+json", ".geojson")
+	r.RegisterExtension("application/xml", ".xml")
+	r.RegisterExtension("text/csv", ".csv")
+	r.RegisterExtension("text/plain", ".txt")
+
+	return r
+}
+
+//DefaultMediaTypes is the registry used by DetectMediaType. Custom media types may be registered
+// with it before jobs are run.
+var DefaultMediaTypes = NewMediaTypeRegistry()
+
+//RegisterMagic identifies files starting with the given bytes at the given offset as mediaType
+func (r *MediaTypeRegistry) RegisterMagic(mediaType string, offset int, magic []byte) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	signature := mediaSignature{mediaType: mediaType, offset: offset, magic: append([]byte{}, magic...)}
+	r.signatures = append([]mediaSignature{signature}, r.signatures...)
+}
+
+//RegisterExtension identifies files with the given extensions (including the leading .) as mediaType
+func (r *MediaTypeRegistry) RegisterExtension(mediaType string, extensions ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, ext := range extensions {
+		r.extensions[strings.ToLower(ext)] = mediaType
+	}
+}
+
+//Detect returns the media type of a file given its name and the first bytes of its contents.
+// Registered magic bytes are checked first, then registered extensions, then the content sniffing
+// of net/http and the system's extension mapping. DefaultMediaType is returned if nothing matches.
+func (r *MediaTypeRegistry) Detect(name string, header []byte) string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, s := range r.signatures {
+		end := s.offset + len(s.magic)
+		if end <= len(header) && bytes.Equal(header[s.offset:end], s.magic) {
+			return s.mediaType
+		}
+	}
+
+	ext := strings.ToLower(filepath.Ext(name))
+	if mediaType, ok := r.extensions[ext]; ok {
+		return mediaType
+	}
+
+	if len(header) > 0 {
+		if sniffed := BaseMediaType(http.DetectContentType(header)); sniffed != DefaultMediaType {
+			return sniffed
+		}
+	}
+	if ext != "" {
+		if mediaType := BaseMediaType(mime.TypeByExtension(ext)); mediaType != "" {
+			return mediaType
+		}
+	}
+
+	return DefaultMediaType
+}
+
+//DetectFile returns the media type of the file at the given path
+func (r *MediaTypeRegistry) DetectFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	header := make([]byte, sniffLength)
+	n, err := io.ReadFull(file, header)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+
+	return r.Detect(path, header[:n]), nil
+}
+
+//DetectMediaType returns the media type of the file at the given path using DefaultMediaTypes
+func DetectMediaType(path string) (string, error) {
+	return DefaultMediaTypes.DetectFile(path)
+}
+
+//BaseMediaType returns the media type without parameters in lower case, i.e. text/plain for
+// "Text/Plain; charset=utf-8"
+func BaseMediaType(mediaType string) string {
+	return strings.ToLower(strings.TrimSpace(strings.SplitN(mediaType, ";", 2)[0]))
+}
+
+//MediaTypeMatches checks if a detected media type satisfies a declared one. Parameters and case
+// are ignored and the declared type may be a wildcard such as image/* or */*.
+func MediaTypeMatches(detected, declared string) bool {
+	detected, declared = BaseMediaType(detected), BaseMediaType(declared)
+	if declared == "*/*" || declared == detected {
+		return true
+	}
+	if strings.HasSuffix(declared, "/*") {
+		return strings.HasPrefix(detected, strings.TrimSuffix(declared, "*"))
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package util
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectMediaType(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mediatype")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	cases := []struct {
+		name      string
+		contents  string
+		mediaType string
+	}{
+		{"in.h5", "\x89HDF\r\n\x1a\n\x00\x00", "image/x-hdf5-image"},
+		{"mislabeled.tif", "\x89HDF\r\n\x1a\n\x00\x00", "image/x-hdf5-image"},
+		{"out.tif", "II*\x00\x08\x00\x00\x00", "image/tiff"},
+		{"big.data", "MM\x00+\x00\x08", "image/tiff"},
+		{"image", "\x89PNG\r\n\x1a\n", "image/png"},
+		{"cells.csv", "a,b\n1,2\n", "text/csv"},
+		{"notes", "just some text\n", "text/plain"},
+		{"results.json", `{"cellCount": 3}`, "application/json"},
+		{"page.html", "<html><body></body></html>", "text/html"},
+		{"empty.tiff", "", "image/tiff"},
+		{"unknown", "\x00\x01\x02\x03", DefaultMediaType},
+	}
+
+	for _, c := range cases {
+		path := filepath.Join(dir, c.name)
+		ioutil.WriteFile(path, []byte(c.contents), 0644)
+		mediaType, err := DetectMediaType(path)
+		if err != nil || mediaType != c.mediaType {
+			t.Errorf("DetectMediaType(%q) returned %v, %v, expected %v", c.name, mediaType, err, c.mediaType)
+		}
+	}
+
+	if _, err := DetectMediaType(filepath.Join(dir, "missing")); err == nil {
+		t.Errorf("DetectMediaType did not return an error for a missing file")
+	}
+}
+
+func TestMediaTypeRegistry(t *testing.T) {
+	registry := NewMediaTypeRegistry()
+	registry.RegisterMagic("application/x-sicd", 4, []byte("SICD"))
+	registry.RegisterExtension("application/x-sicd", ".SICD")
+	registry.RegisterExtension("application/x-hdf5", ".h5")
+
+	cases := []struct {
+		name      string
+		header    string
+		mediaType string
+	}{
+		{"a.bin", "\x00\x00\x00\x00SICD", "application/x-sicd"},
+		{"a.bin", "\x00\x00\x00\x00SIC", DefaultMediaType},
+		{"A.Sicd", "", "application/x-sicd"},
+		{"a.h5", "", "application/x-hdf5"},
+		{"a.h5", "\x89HDF\r\n\x1a\n", "image/x-hdf5-image"},
+	}
+
+	for _, c := range cases {
+		if mediaType := registry.Detect(c.name, []byte(c.header)); mediaType != c.mediaType {
+			t.Errorf("Detect(%q, %q) returned %v, expected %v", c.name, c.header, mediaType, c.mediaType)
+		}
+	}
+
+	if mediaType := DefaultMediaTypes.Detect("a.bin", []byte("\x00\x00\x00\x00SICD")); mediaType != DefaultMediaType {
+		t.Errorf("Registering with a new registry changed DefaultMediaTypes: %v", mediaType)
+	}
+}
+
+func TestMediaTypeMatches(t *testing.T) {
+	cases := []struct {
+		detected string
+		declared string
+		matches  bool
+	}{
+		{"image/tiff", "image/tiff", true},
+		{"image/tiff", "Image/TIFF", true},
+		{"text/plain; charset=utf-8", "text/plain", true},
+		{"image/tiff", "image/*", true},
+		{"image/tiff", "*/*", true},
+		{"image/tiff", "image/png", false},
+		{"imagery/tiff", "image/*", false},
+		{"text/csv", "text/plain", false},
+	}
+
+	for _, c := range cases {
+		if matches := MediaTypeMatches(c.detected, c.declared); matches != c.matches {
+			t.Errorf("MediaTypeMatches(%q, %q) returned %v, expected %v", c.detected, c.declared, matches, c.matches)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ngageoint/seed-common/constants"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+//ValidationError describes a single violation of a seed schema
+type ValidationError struct {
+	Path        string
+	Type        string
+	Description string
+}
+
+//String formats the validation error as path: description
+func (e ValidationError) String() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Description)
+}
+
+//GetSchema returns the embedded JSON schema for the given schema type
+func GetSchema(schema constants.SchemaType) (string, error) {
+	switch schema {
+	case constants.SchemaManifest:
+		return constants.SeedManifestSchema, nil
+	case constants.SchemaMetadata:
+		return constants.SeedMetadataSchema, nil
+	}
+
+	msg := fmt.Sprintf("ERROR: Unknown schema type %d", schema)
+	return "", errors.New(msg)
+}
+
+//Validate validates the given seed document against the embedded schema of the given type.
+// Every violation found is returned along with the JSON path of the offending field. The error
+// is only set if the document could not be validated at all (i.e. it is not valid JSON).
+func Validate(seed []byte, schema constants.SchemaType) ([]ValidationError, error) {
+	schemaStr, err := GetSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaLoader := gojsonschema.NewStringLoader(schemaStr)
+	docLoader := gojsonschema.NewBytesLoader(seed)
+
+	result, err := gojsonschema.Validate(schemaLoader, docLoader)
+	if err != nil {
+		msg := fmt.Sprintf("ERROR: Unable to validate seed document. %s", err.Error())
+		return nil, errors.New(msg)
+	}
+
+	validationErrors := []ValidationError{}
+	for _, desc := range result.Errors() {
+		validationErrors = append(validationErrors, ValidationError{
+			Path:        validationPath(desc),
+			Type:        desc.Type(),
+			Description: desc.Description(),
+		})
+	}
+
+	return validationErrors, nil
+}
+
+//validationPath converts the context of a schema error into a JSON path such as
+// job.interface.inputs.files[0].name
+func validationPath(desc gojsonschema.ResultError) string {
+	// split on a separator not expected in a key so dotted keys survive
+	parts := strings.Split(desc.Context().String("\x00"), "\x00")
+
+	// required errors are reported against the parent object
+	if desc.Type() == "required" {
+		if property, ok := desc.Details()["property"].(string); ok {
+			parts = append(parts, property)
+		}
+	}
+
+	path := ""
+	for _, part := range parts {
+		if part == gojsonschema.STRING_CONTEXT_ROOT {
+			continue
+		}
+		if _, err := strconv.Atoi(part); err == nil {
+			path += "[" + part + "]"
+		} else if path == "" {
+			path = part
+		} else {
+			path += "." + part
+		}
+	}
+
+	if path == "" {
+		path = gojsonschema.STRING_CONTEXT_ROOT
+	}
+
+	return path
+}
@@ -0,0 +1,64 @@
+package util
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/ngageoint/seed-common/constants"
+)
+
+func TestValidate(t *testing.T) {
+	complete, _ := ioutil.ReadFile("../testdata/complete/seed.manifest.json")
+
+	cases := []struct {
+		seed   string
+		schema constants.SchemaType
+		paths  []string
+		errStr string
+	}{
+		{`{"seedVersion": "1.0.0", "job": {"name": "my-job", "jobVersion": "0.1.0", "packageVersion": "0.1.0",
+			"title": "Title", "description": "Desc", "timeout": 10, "maintainer": {"name": "Jane", "email": "j@example.com"}}}`,
+			constants.SchemaManifest, []string{}, ""},
+		{`{"seedVersion": "1.0.0", "job": {"name": "my-job", "jobVersion": "0.1.0", "packageVersion": "0.1.0",
+			"description": "Desc", "timeout": 10, "maintainer": {"name": "Jane"}}}`,
+			constants.SchemaManifest, []string{"job.title", "job.maintainer.email"}, ""},
+		{`{"job": {"name": "My Job", "jobVersion": "1", "packageVersion": "0.1.0", "title": "Title",
+			"description": "Desc", "timeout": 10, "maintainer": {"name": "Jane", "email": "j@example.com"},
+			"interface": {"inputs": {"files": [{"name": "INPUT", "required": "yes"}]}}}}`,
+			constants.SchemaManifest, []string{"seedVersion", "job.name", "job.jobVersion", "job.interface.inputs.files[0].required"}, ""},
+		{string(complete), constants.SchemaManifest, []string{}, ""},
+		{`{"seedVersion": "1.0.0", "job": {"name": "my-job", "jobVersion": "0.1.0", "packageVersion": "0.1.0",
+			"title": "Title", "description": "Desc", "timeout": 10, "maintainer": {"name": "Jane", "email": "j@example.com"},
+			"errors": [{"code": 1, "title": "Error"}, {"code": 2, "name": "error-two", "title": "Error"}]}}`,
+			constants.SchemaManifest, []string{"job.errors[0].name"}, ""},
+		{`{"type": "Feature", "properties": {"dataStarted": "2017-01-01T00:00:00Z"}}`, constants.SchemaMetadata, []string{}, ""},
+		{`{"type": "Feature", "properties": {"dataStarted": "yesterday"}}`, constants.SchemaMetadata,
+			[]string{"properties.dataStarted"}, ""},
+		{`{"seedVersion": `, constants.SchemaManifest, []string{}, "Unable to validate seed document"},
+		{`{}`, constants.SchemaType(42), []string{}, "Unknown schema type 42"},
+	}
+
+	for _, c := range cases {
+		errs, err := Validate([]byte(c.seed), c.schema)
+
+		if err == nil && len(errs) != len(c.paths) {
+			t.Errorf("Validate(%q) returned %v, expected errors for %v", c.seed, errs, c.paths)
+		}
+		for _, path := range c.paths {
+			found := false
+			for _, e := range errs {
+				found = found || e.Path == path
+			}
+			if !found {
+				t.Errorf("Validate(%q) returned %v, expected an error for %v", c.seed, errs, path)
+			}
+		}
+		if err == nil && c.errStr != "" {
+			t.Errorf("Validate(%q) did not return an error when one was expected: %s", c.seed, c.errStr)
+		}
+		if err != nil && !strings.Contains(err.Error(), c.errStr) {
+			t.Errorf("Validate(%q) returned an error: %v\n expected %v", c.seed, err, c.errStr)
+		}
+	}
+}
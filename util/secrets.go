@@ -0,0 +1,274 @@
+package util
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+//SecretProvider resolves the values of secret settings by name. Providers do not register the
+// values they return for redaction; callers register them with RegisterSecret while they are in use.
+type SecretProvider interface {
+	GetSecret(name string) (string, error)
+}
+
+//SecretNotFoundError is returned by a SecretProvider that has no value for a secret
+type SecretNotFoundError struct {
+	Name     string
+	Provider string
+}
+
+func (e *SecretNotFoundError) Error() string {
+	return fmt.Sprintf("ERROR: Secret %s not found in %s", e.Name, e.Provider)
+}
+
+//IsSecretNotFound checks if the error is a *SecretNotFoundError
+func IsSecretNotFound(err error) bool {
+	_, ok := err.(*SecretNotFoundError)
+	return ok
+}
+
+//FileSecretProvider reads each secret from a file named after the secret in Dir, i.e. a docker or
+// kubernetes secrets mount. A single trailing newline is removed from the value.
+type FileSecretProvider struct {
+	Dir string
+}
+
+//GetSecret returns the contents of Dir/name
+func (p *FileSecretProvider) GetSecret(name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		msg := fmt.Sprintf("ERROR: Invalid secret name %q", name)
+		return "", errors.New(msg)
+	}
+	value, err := ioutil.ReadFile(filepath.Join(p.Dir, name))
+	if os.IsNotExist(err) {
+		return "", &SecretNotFoundError{Name: name, Provider: p.Dir}
+	} else if err != nil {
+		return "", err
+	}
+	secret := strings.TrimSuffix(strings.TrimSuffix(string(value), "\n"), "\r")
+	return secret, nil
+}
+
+//EnvSecretProvider reads each secret from the environment variable Prefix + name
+type EnvSecretProvider struct {
+	Prefix string
+}
+
+//GetSecret returns the value of the environment variable Prefix + name
+func (p *EnvSecretProvider) GetSecret(name string) (string, error) {
+	value, ok := os.LookupEnv(p.Prefix + name)
+	if !ok {
+		return "", &SecretNotFoundError{Name: name, Provider: "the environment"}
+	}
+	return value, nil
+}
+
+//ChainSecretProvider returns the secret from the first provider that has it
+type ChainSecretProvider []SecretProvider
+
+//GetSecret returns the secret from the first provider that does not return a *SecretNotFoundError
+func (c ChainSecretProvider) GetSecret(name string) (string, error) {
+	for _, provider := range c {
+		value, err := provider.GetSecret(name)
+		if !IsSecretNotFound(err) {
+			return value, err
+		}
+	}
+	return "", &SecretNotFoundError{Name: name, Provider: "any secret provider"}
+}
+
+//encryptedSecrets is the on disk format of an encrypted secrets file
+type encryptedSecrets struct {
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+//secretKeyIterations is the PBKDF2 iteration count used to derive encryption keys from passphrases
+const secretKeyIterations = 100000
+
+//EncryptedFileSecretProvider reads secrets from a local file encrypted with AES-256-GCM using a key
+// derived from a passphrase. Files are written with WriteEncryptedSecrets.
+type EncryptedFileSecretProvider struct {
+	path    string
+	secrets map[string]string
+}
+
+//NewEncryptedFileSecretProvider decrypts the secrets file at path with the given passphrase
+func NewEncryptedFileSecretProvider(path, passphrase string) (*EncryptedFileSecretProvider, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file encryptedSecrets
+	if err = json.Unmarshal(data, &file); err != nil {
+		msg := fmt.Sprintf("ERROR: Error parsing secrets file %s. %s", path, err.Error())
+		return nil, errors.New(msg)
+	}
+
+	gcm, err := secretCipher(passphrase, file.Salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(file.Nonce) != gcm.NonceSize() {
+		msg := fmt.Sprintf("ERROR: Secrets file %s is corrupt", path)
+		return nil, errors.New(msg)
+	}
+	plaintext, err := gcm.Open(nil, file.Nonce, file.Ciphertext, nil)
+	if err != nil {
+		msg := fmt.Sprintf("ERROR: Unable to decrypt secrets file %s; the passphrase may be incorrect", path)
+		return nil, errors.New(msg)
+	}
+
+	provider := &EncryptedFileSecretProvider{path: path, secrets: make(map[string]string)}
+	if err = json.Unmarshal(plaintext, &provider.secrets); err != nil {
+		msg := fmt.Sprintf("ERROR: Error parsing secrets file %s. %s", path, err.Error())
+		return nil, errors.New(msg)
+	}
+	return provider, nil
+}
+
+//GetSecret returns the named secret from the decrypted file
+func (p *EncryptedFileSecretProvider) GetSecret(name string) (string, error) {
+	value, ok := p.secrets[name]
+	if !ok {
+		return "", &SecretNotFoundError{Name: name, Provider: p.path}
+	}
+	return value, nil
+}
+
+//WriteEncryptedSecrets encrypts the given secrets with a key derived from the passphrase and
+// writes them to path, readable only by the current user
+func WriteEncryptedSecrets(path, passphrase string, secrets map[string]string) error {
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return err
+	}
+
+	file := encryptedSecrets{Salt: make([]byte, 16)}
+	if _, err = rand.Read(file.Salt); err != nil {
+		return err
+	}
+	gcm, err := secretCipher(passphrase, file.Salt)
+	if err != nil {
+		return err
+	}
+	file.Nonce = make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(file.Nonce); err != nil {
+		return err
+	}
+	file.Ciphertext = gcm.Seal(nil, file.Nonce, plaintext, nil)
+
+	data, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+//secretCipher returns the AES-256-GCM cipher for the passphrase and salt
+func secretCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	if passphrase == "" {
+		return nil, errors.New("ERROR: A passphrase is required for encrypted secrets")
+	}
+	block, err := aes.NewCipher(pbkdf2.Key([]byte(passphrase), salt, secretKeyIterations, 32, sha256.New))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+//RedactedValue replaces secret values in redacted text
+const RedactedValue = "********"
+
+//MinSecretLength is the length below which secret values are not redacted. Redacting short values
+// such as 1, true or seed would replace every occurrence of them in unrelated messages.
+const MinSecretLength = 6
+
+//secretValues holds the registered secret values, longest first, and how many times each was
+// registered
+var secretValues = struct {
+	sync.RWMutex
+	values []string
+	counts map[string]int
+}{counts: make(map[string]int)}
+
+//RegisterSecret adds a value that Redact, and therefore PrintUtil, will remove from messages until
+// it is unregistered. The returned function unregisters it, i.e.
+//
+//	defer util.RegisterSecret(password)()
+//
+// Values are counted so a secret registered twice is redacted until both registrations are
+// released. Values shorter than MinSecretLength are ignored.
+func RegisterSecret(value string) func() {
+	if len(strings.TrimSpace(value)) < MinSecretLength {
+		return func() {}
+	}
+	secretValues.Lock()
+	defer secretValues.Unlock()
+	secretValues.counts[value]++
+	if secretValues.counts[value] == 1 {
+		secretValues.values = append(secretValues.values, value)
+		// replace longer values first so a secret containing another is fully redacted
+		sort.SliceStable(secretValues.values, func(i, j int) bool {
+			return len(secretValues.values[i]) > len(secretValues.values[j])
+		})
+	}
+
+	var once sync.Once
+	return func() { once.Do(func() { UnregisterSecret(value) }) }
+}
+
+//UnregisterSecret releases one registration of a secret value. The value is no longer redacted
+// once every registration is released.
+func UnregisterSecret(value string) {
+	secretValues.Lock()
+	defer secretValues.Unlock()
+	if secretValues.counts[value] == 0 {
+		return
+	}
+	secretValues.counts[value]--
+	if secretValues.counts[value] > 0 {
+		return
+	}
+	delete(secretValues.counts, value)
+	for i, v := range secretValues.values {
+		if v == value {
+			secretValues.values = append(secretValues.values[:i], secretValues.values[i+1:]...)
+			break
+		}
+	}
+}
+
+//Redact replaces every registered secret value in the text with RedactedValue
+func Redact(text string) string {
+	secretValues.RLock()
+	defer secretValues.RUnlock()
+	for _, value := range secretValues.values {
+		text = strings.Replace(text, value, RedactedValue, -1)
+	}
+	return text
+}
+
+//RedactingPrinter wraps a PrintCallback so that registered secret values are redacted
+func RedactingPrinter(callback PrintCallback) PrintCallback {
+	if callback == nil {
+		return nil
+	}
+	return func(format string, args ...interface{}) {
+		callback("%s", Redact(fmt.Sprintf(format, args...)))
+	}
+}
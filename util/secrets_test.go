@@ -0,0 +1,132 @@
+package util
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSecretProviders(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secrets")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	ioutil.WriteFile(filepath.Join(dir, "DB_PASS"), []byte("file-pass\n"), 0600)
+	os.Setenv("SEED_SECRET_API_KEY", "env-key")
+	defer os.Unsetenv("SEED_SECRET_API_KEY")
+	encrypted := filepath.Join(dir, "secrets.enc")
+	if err = WriteEncryptedSecrets(encrypted, "passphrase", map[string]string{"TOKEN": "enc-token"}); err != nil {
+		t.Fatalf("Error writing encrypted secrets: %v", err)
+	}
+	encryptedProvider, err := NewEncryptedFileSecretProvider(encrypted, "passphrase")
+	if err != nil {
+		t.Fatalf("Error reading encrypted secrets: %v", err)
+	}
+
+	files := &FileSecretProvider{Dir: dir}
+	env := &EnvSecretProvider{Prefix: "SEED_SECRET_"}
+	chain := ChainSecretProvider{files, env, encryptedProvider}
+
+	cases := []struct {
+		provider SecretProvider
+		name     string
+		value    string
+		errStr   string
+	}{
+		{files, "DB_PASS", "file-pass", ""},
+		{files, "API_KEY", "", "Secret API_KEY not found"},
+		{files, "../DB_PASS", "", "Invalid secret name"},
+		{env, "API_KEY", "env-key", ""},
+		{env, "DB_PASS", "", "not found in the environment"},
+		{encryptedProvider, "TOKEN", "enc-token", ""},
+		{encryptedProvider, "DB_PASS", "", "not found in " + encrypted},
+		{chain, "DB_PASS", "file-pass", ""},
+		{chain, "API_KEY", "env-key", ""},
+		{chain, "TOKEN", "enc-token", ""},
+		{chain, "OTHER", "", "not found in any secret provider"},
+	}
+
+	for _, c := range cases {
+		value, err := c.provider.GetSecret(c.name)
+		if value != c.value {
+			t.Errorf("%T.GetSecret(%q) returned %q, expected %q", c.provider, c.name, value, c.value)
+		}
+		if err == nil && c.errStr != "" {
+			t.Errorf("%T.GetSecret(%q) did not return an error when one was expected: %v", c.provider, c.name, c.errStr)
+		}
+		if err != nil && (c.errStr == "" || !strings.Contains(err.Error(), c.errStr)) {
+			t.Errorf("%T.GetSecret(%q) returned an error: %v\n expected %v", c.provider, c.name, err, c.errStr)
+		}
+		if Redact("value "+c.value) != "value "+c.value {
+			t.Errorf("%T.GetSecret(%q) registered the secret for redaction", c.provider, c.name)
+		}
+	}
+
+	if _, err = NewEncryptedFileSecretProvider(encrypted, "wrong"); err == nil || !strings.Contains(err.Error(), "passphrase may be incorrect") {
+		t.Errorf("NewEncryptedFileSecretProvider with the wrong passphrase returned %v", err)
+	}
+	if data, _ := ioutil.ReadFile(encrypted); strings.Contains(string(data), "enc-token") {
+		t.Errorf("WriteEncryptedSecrets wrote the secret in plain text: %s", data)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	defer RegisterSecret("hunter2")()
+	defer RegisterSecret("hunter2-extended")()
+	defer RegisterSecret(" ")()
+	// short values are not redacted
+	defer RegisterSecret("true")()
+	defer RegisterSecret("seed")()
+
+	var printed string
+	printer := RedactingPrinter(func(format string, args ...interface{}) {
+		printed = format
+		if len(args) > 0 {
+			printed = args[0].(string)
+		}
+	})
+
+	cases := []struct {
+		input  string
+		output string
+	}{
+		{"password=hunter2", "password=********"},
+		{"token hunter2-extended.", "token ********."},
+		{"no secrets here", "no secrets here"},
+		{"seed run --verbose=true", "seed run --verbose=true"},
+	}
+
+	for _, c := range cases {
+		if out := Redact(c.input); out != c.output {
+			t.Errorf("Redact(%q) returned %q, expected %q", c.input, out, c.output)
+		}
+		printer("%s", c.input)
+		if printed != c.output {
+			t.Errorf("RedactingPrinter printed %q, expected %q", printed, c.output)
+		}
+	}
+}
+
+func TestUnregisterSecret(t *testing.T) {
+	unregister := RegisterSecret("released-secret")
+	RegisterSecret("released-secret")
+	if out := Redact("released-secret"); out != RedactedValue {
+		t.Errorf("Redact returned %q for a registered secret", out)
+	}
+
+	// the secret stays registered until every registration is released
+	unregister()
+	unregister()
+	if out := Redact("released-secret"); out != RedactedValue {
+		t.Errorf("Redact returned %q after releasing one of two registrations", out)
+	}
+	UnregisterSecret("released-secret")
+	if out := Redact("released-secret"); out != "released-secret" {
+		t.Errorf("Redact returned %q for an unregistered secret", out)
+	}
+	UnregisterSecret("never-registered")
+}
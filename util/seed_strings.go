@@ -6,10 +6,14 @@ import (
 	"strings"
 )
 
+//UnescapeManifestLabel returns the manifest stored in a com.ngageoint.seed.manifest label.
+// Deprecated: use DecodeManifestLabel, which also reports errors and the encoding that was used.
+// Labels that DecodeManifestLabel cannot decode fall back to removing escape characters.
 func UnescapeManifestLabel(label string) string {
-	if len(label) == 0 {
-		return label
+	if manifest, _, err := DecodeManifestLabel(label); err == nil {
+		return manifest
 	}
+
 	// un-escape special characters
 	seedStr := label
 	seedStr = strings.Replace(seedStr, "\\\"", "\"", -1)
@@ -19,7 +23,7 @@ func UnescapeManifestLabel(label string) string {
 	seedStr = strings.TrimSpace(seedStr)
 	seedStr = strings.TrimSuffix(strings.TrimPrefix(seedStr, "'\""), "\"'")
 
-	if seedStr[0] == '"' { //fix quoted string
+	if len(seedStr) >= 2 && seedStr[0] == '"' { //fix quoted string
 		seedStr = seedStr[1 : len(seedStr)-1]
 	}
 
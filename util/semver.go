@@ -0,0 +1,312 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//Version represents a semantic version (https://semver.org) such as a seedVersion, jobVersion or
+// packageVersion
+type Version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	PreRelease []string
+	Build      []string
+}
+
+var semverRegex = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+var looseVersionRegex = regexp.MustCompile(`^v?(\d+)(?:\.(\d+))?(?:\.(\d+))?` +
+	`(?:-([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+//ParseVersion parses a strict semantic version, including any pre-release and build metadata
+func ParseVersion(version string) (Version, error) {
+	match := semverRegex.FindStringSubmatch(version)
+	if match == nil {
+		msg := fmt.Sprintf("ERROR: %q is not a valid semantic version", version)
+		return Version{}, errors.New(msg)
+	}
+	return versionFromMatch(match)
+}
+
+//ParseLooseVersion parses a version that may be missing its minor or patch number, have leading
+// zeros or a leading v, i.e. docker's 17.06.0-ce
+func ParseLooseVersion(version string) (Version, error) {
+	match := looseVersionRegex.FindStringSubmatch(strings.TrimSpace(version))
+	if match == nil {
+		msg := fmt.Sprintf("ERROR: %q is not a valid version", version)
+		return Version{}, errors.New(msg)
+	}
+	return versionFromMatch(match)
+}
+
+//IsValidVersion checks if the given string is a strict semantic version
+func IsValidVersion(version string) bool {
+	return semverRegex.MatchString(version)
+}
+
+func versionFromMatch(match []string) (Version, error) {
+	v := Version{}
+	numbers := []*uint64{&v.Major, &v.Minor, &v.Patch}
+	for i, n := range numbers {
+		if match[i+1] == "" {
+			continue
+		}
+		num, err := strconv.ParseUint(match[i+1], 10, 64)
+		if err != nil {
+			return Version{}, err
+		}
+		*n = num
+	}
+	if match[4] != "" {
+		v.PreRelease = strings.Split(match[4], ".")
+	}
+	if match[5] != "" {
+		v.Build = strings.Split(match[5], ".")
+	}
+	return v, nil
+}
+
+//String formats the version as MAJOR.MINOR.PATCH[-PRERELEASE][+BUILD]
+func (v Version) String() string {
+	str := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.PreRelease) > 0 {
+		str += "-" + strings.Join(v.PreRelease, ".")
+	}
+	if len(v.Build) > 0 {
+		str += "+" + strings.Join(v.Build, ".")
+	}
+	return str
+}
+
+//Compare returns -1, 0 or 1 if v has lower, equal or higher precedence than o. Build metadata is
+// ignored as required by the semver spec.
+func (v Version) Compare(o Version) int {
+	if c := compareUint(v.Major, o.Major); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Minor, o.Minor); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Patch, o.Patch); c != 0 {
+		return c
+	}
+
+	// a version without a pre-release has higher precedence
+	switch {
+	case len(v.PreRelease) == 0 && len(o.PreRelease) == 0:
+		return 0
+	case len(v.PreRelease) == 0:
+		return 1
+	case len(o.PreRelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.PreRelease) && i < len(o.PreRelease); i++ {
+		if c := comparePreRelease(v.PreRelease[i], o.PreRelease[i]); c != 0 {
+			return c
+		}
+	}
+	return compareUint(uint64(len(v.PreRelease)), uint64(len(o.PreRelease)))
+}
+
+//LessThan checks if v has lower precedence than o
+func (v Version) LessThan(o Version) bool {
+	return v.Compare(o) < 0
+}
+
+//Equal checks if v has the same precedence as o
+func (v Version) Equal(o Version) bool {
+	return v.Compare(o) == 0
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+//comparePreRelease compares pre-release identifiers; numeric identifiers sort before alphanumeric ones
+func comparePreRelease(a, b string) int {
+	aNum, aErr := strconv.ParseUint(a, 10, 64)
+	bNum, bErr := strconv.ParseUint(b, 10, 64)
+	switch {
+	case aErr == nil && bErr == nil:
+		return compareUint(aNum, bNum)
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+//SortVersions sorts the given strings (i.e. image tags) by semantic version precedence. Strings
+// that are not versions are sorted alphabetically after all of the versions.
+func SortVersions(versions []string) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		vi, errI := ParseLooseVersion(versions[i])
+		vj, errJ := ParseLooseVersion(versions[j])
+		switch {
+		case errI == nil && errJ == nil:
+			if c := vi.Compare(vj); c != 0 {
+				return c < 0
+			}
+			return versions[i] < versions[j]
+		case errI == nil:
+			return true
+		case errJ == nil:
+			return false
+		}
+		return versions[i] < versions[j]
+	})
+}
+
+//versionComparison is a single operator and version, i.e. >=0.3.0
+type versionComparison struct {
+	op      string
+	version Version
+}
+
+//Constraint is a set of version requirements such as "^1.2", ">=0.3 <1.0" or "~1.2.3 || >=2.0".
+// Space separated comparisons must all match; || separates alternatives.
+type Constraint struct {
+	raw  string
+	sets [][]versionComparison
+}
+
+var constraintRegex = regexp.MustCompile(`^(\^|~|>=|<=|!=|>|<|=)?\s*(.+)$`)
+
+//ParseConstraint parses a version constraint. Versions within a constraint may be partial, i.e. ^1.2
+func ParseConstraint(constraint string) (Constraint, error) {
+	c := Constraint{raw: constraint}
+	for _, alternative := range strings.Split(constraint, "||") {
+		set := []versionComparison{}
+		fields := strings.Fields(alternative)
+		// allow a space between an operator and its version, i.e. ">= 1.0"
+		for i := 0; i < len(fields); i++ {
+			field := fields[i]
+			if strings.Trim(field, "^~<>=!") == "" && i+1 < len(fields) {
+				i++
+				field += fields[i]
+			}
+			comparisons, err := parseComparison(field)
+			if err != nil {
+				return Constraint{}, err
+			}
+			set = append(set, comparisons...)
+		}
+		if len(set) == 0 {
+			msg := fmt.Sprintf("ERROR: Empty version constraint in %q", constraint)
+			return Constraint{}, errors.New(msg)
+		}
+		c.sets = append(c.sets, set)
+	}
+	return c, nil
+}
+
+//parseComparison converts a single constraint term into primitive comparisons
+func parseComparison(term string) ([]versionComparison, error) {
+	match := constraintRegex.FindStringSubmatch(term)
+	if match == nil {
+		msg := fmt.Sprintf("ERROR: Invalid version constraint %q", term)
+		return nil, errors.New(msg)
+	}
+	op, versionStr := match[1], match[2]
+
+	version, err := ParseLooseVersion(versionStr)
+	if err != nil {
+		msg := fmt.Sprintf("ERROR: Invalid version constraint %q", term)
+		return nil, errors.New(msg)
+	}
+	parts := len(strings.Split(strings.SplitN(strings.SplitN(strings.TrimPrefix(versionStr, "v"), "-", 2)[0], "+", 2)[0], "."))
+
+	switch op {
+	case "^":
+		// changes that do not modify the left-most non-zero part are allowed
+		upper := Version{}
+		switch {
+		case version.Major > 0 || parts == 1:
+			upper.Major = version.Major + 1
+		case version.Minor > 0 || parts == 2:
+			upper.Minor = version.Minor + 1
+		default:
+			upper.Patch = version.Patch + 1
+		}
+		return []versionComparison{{">=", version}, {"<", upper}}, nil
+	case "~":
+		upper := Version{Major: version.Major, Minor: version.Minor + 1}
+		if parts == 1 {
+			upper = Version{Major: version.Major + 1}
+		}
+		return []versionComparison{{">=", version}, {"<", upper}}, nil
+	case "", "=":
+		// a partial version matches any version with the same prefix
+		if parts == 1 {
+			return []versionComparison{{">=", version}, {"<", Version{Major: version.Major + 1}}}, nil
+		} else if parts == 2 {
+			return []versionComparison{{">=", version}, {"<", Version{Major: version.Major, Minor: version.Minor + 1}}}, nil
+		}
+		return []versionComparison{{"=", version}}, nil
+	}
+
+	return []versionComparison{{op, version}}, nil
+}
+
+//Check returns true if the given version satisfies the constraint
+func (c Constraint) Check(v Version) bool {
+	for _, set := range c.sets {
+		matches := true
+		for _, comparison := range set {
+			matches = matches && comparison.check(v)
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+//CheckString parses the given version and checks it against the constraint
+func (c Constraint) CheckString(version string) bool {
+	v, err := ParseVersion(version)
+	if err != nil {
+		return false
+	}
+	return c.Check(v)
+}
+
+//String returns the constraint as it was given
+func (c Constraint) String() string {
+	return c.raw
+}
+
+func (vc versionComparison) check(v Version) bool {
+	cmp := v.Compare(vc.version)
+	switch vc.op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+	return false
+}
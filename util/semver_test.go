@@ -0,0 +1,131 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	cases := []struct {
+		version string
+		output  string
+		errStr  string
+	}{
+		{"1.0.0", "1.0.0", ""},
+		{"0.1.2-alpha.1+build.5", "0.1.2-alpha.1+build.5", ""},
+		{"1.0", "0.0.0", "not a valid semantic version"},
+		{"01.0.0", "0.0.0", "not a valid semantic version"},
+		{"1.0.0-01", "0.0.0", "not a valid semantic version"},
+		{"", "0.0.0", "not a valid semantic version"},
+	}
+
+	for _, c := range cases {
+		v, err := ParseVersion(c.version)
+		if v.String() != c.output {
+			t.Errorf("ParseVersion(%q) returned %v, expected %v", c.version, v, c.output)
+		}
+		if err == nil && c.errStr != "" {
+			t.Errorf("ParseVersion(%q) did not return an error when one was expected: %v", c.version, c.errStr)
+		}
+		if err != nil && !strings.Contains(err.Error(), c.errStr) {
+			t.Errorf("ParseVersion(%q) returned an error: %v\n expected %v", c.version, err, c.errStr)
+		}
+	}
+}
+
+func TestParseLooseVersion(t *testing.T) {
+	cases := []struct {
+		version string
+		output  string
+	}{
+		{"17.06.0-ce", "17.6.0-ce"},
+		{"v1.13", "1.13.0"},
+		{"20.10.7+dfsg1\n", "20.10.7+dfsg1"},
+		{"latest", "0.0.0"},
+	}
+
+	for _, c := range cases {
+		v, _ := ParseLooseVersion(c.version)
+		if v.String() != c.output {
+			t.Errorf("ParseLooseVersion(%q) returned %v, expected %v", c.version, v, c.output)
+		}
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	cases := []struct {
+		a      string
+		b      string
+		result int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"1.0.0", "1.0.1", -1},
+		{"1.10.0", "1.9.0", 1},
+		{"1.0.0-alpha", "1.0.0", -1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-alpha.beta", "1.0.0-alpha.1", 1},
+		{"1.0.0-beta.11", "1.0.0-beta.2", 1},
+		{"1.0.0+build.1", "1.0.0+build.2", 0},
+	}
+
+	for _, c := range cases {
+		a, _ := ParseVersion(c.a)
+		b, _ := ParseVersion(c.b)
+		if result := a.Compare(b); result != c.result {
+			t.Errorf("Compare(%v, %v) returned %d, expected %d", c.a, c.b, result, c.result)
+		}
+	}
+}
+
+func TestConstraint(t *testing.T) {
+	cases := []struct {
+		constraint string
+		version    string
+		result     bool
+		errStr     string
+	}{
+		{"^1.2", "1.2.0", true, ""},
+		{"^1.2", "1.9.3", true, ""},
+		{"^1.2", "2.0.0", false, ""},
+		{"^1.2", "1.1.9", false, ""},
+		{"^0.3", "0.3.5", true, ""},
+		{"^0.3", "0.4.0", false, ""},
+		{"^0.0.3", "0.0.4", false, ""},
+		{"~1.2.3", "1.2.9", true, ""},
+		{"~1.2.3", "1.3.0", false, ""},
+		{">=0.3 <1.0", "0.9.9", true, ""},
+		{">=0.3 <1.0", "1.0.0", false, ""},
+		{">= 0.3 < 1.0", "0.2.0", false, ""},
+		{"1.0", "1.0.7", true, ""},
+		{"=1.0.0", "1.0.1", false, ""},
+		{"!=1.0.0", "1.0.1", true, ""},
+		{"<0.5 || >=2.0", "3.0.0", true, ""},
+		{"<0.5 || >=2.0", "1.0.0", false, ""},
+		{">=abc", "1.0.0", false, "Invalid version constraint"},
+		{"1.0 ||", "1.0.0", false, "Empty version constraint"},
+	}
+
+	for _, c := range cases {
+		constraint, err := ParseConstraint(c.constraint)
+		if err == nil && constraint.CheckString(c.version) != c.result {
+			t.Errorf("Constraint(%q).Check(%v) returned %v, expected %v", c.constraint, c.version, !c.result, c.result)
+		}
+		if err == nil && c.errStr != "" {
+			t.Errorf("ParseConstraint(%q) did not return an error when one was expected: %v", c.constraint, c.errStr)
+		}
+		if err != nil && (c.errStr == "" || !strings.Contains(err.Error(), c.errStr)) {
+			t.Errorf("ParseConstraint(%q) returned an error: %v\n expected %v", c.constraint, err, c.errStr)
+		}
+	}
+}
+
+func TestSortVersions(t *testing.T) {
+	tags := []string{"latest", "1.10.0", "1.2.0", "1.2.0-rc.1", "0.1.0", "dev", "1.9.0"}
+	SortVersions(tags)
+
+	expected := "[0.1.0 1.2.0-rc.1 1.2.0 1.9.0 1.10.0 dev latest]"
+	if fmt.Sprintf("%s", tags) != expected {
+		t.Errorf("SortVersions returned %s, expected %s", tags, expected)
+	}
+}
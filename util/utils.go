@@ -36,8 +36,10 @@ var PrintUtil PrintCallback
 var StdErr io.Writer
 var StdOut io.Writer
 
+//InitPrinter sets the printer and output streams used by the library. Messages printed through
+// PrintUtil have any values registered with RegisterSecret redacted.
 func InitPrinter(callback PrintCallback, stderr, stdout io.Writer) {
-	PrintUtil = callback
+	PrintUtil = RedactingPrinter(callback)
 	StdErr = stderr
 	StdOut = stdout
 }
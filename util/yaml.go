@@ -0,0 +1,113 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ngageoint/seed-common/constants"
+	"gopkg.in/yaml.v2"
+)
+
+//YamlToJson converts a YAML document (i.e. a seed.manifest.yaml) into the equivalent compact JSON.
+// Object keys are emitted in sorted order. Scalars in fields the seed manifest schema declares as
+// strings keep their original text, so jobVersion: 1.0 stays "1.0" and name: on stays "on" instead
+// of being converted to a number or boolean.
+func YamlToJson(yamlBytes []byte) ([]byte, error) {
+	var doc yamlValue
+	if err := yaml.Unmarshal(yamlBytes, &doc); err != nil {
+		return nil, err
+	}
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal([]byte(constants.SeedManifestSchema), &schema); err != nil {
+		return nil, err
+	}
+
+	converted, err := doc.toJson(schema, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(converted)
+}
+
+//yamlValue is a decoded YAML value that keeps the original text of scalars. Null values are left
+// as the zero yamlValue.
+type yamlValue struct {
+	mapping  map[interface{}]yamlValue
+	sequence []yamlValue
+	scalar   interface{}
+	text     string
+}
+
+//UnmarshalYAML decodes the value generically to learn its kind and then again to keep the
+// original text of scalars and of the scalars nested in mappings and sequences
+func (v *yamlValue) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var generic interface{}
+	if err := unmarshal(&generic); err != nil {
+		return err
+	}
+
+	switch generic.(type) {
+	case map[interface{}]interface{}:
+		v.mapping = make(map[interface{}]yamlValue)
+		return unmarshal(&v.mapping)
+	case []interface{}:
+		return unmarshal(&v.sequence)
+	}
+	v.scalar = generic
+	return unmarshal(&v.text)
+}
+
+//toJson converts the value into maps with string keys, using the original text of scalars where
+// the schema expects a string. Values without a schema keep their YAML types.
+func (v yamlValue) toJson(schema, root map[string]interface{}) (interface{}, error) {
+	schema = resolveSchemaRef(schema, root)
+
+	switch {
+	case v.mapping != nil:
+		properties, _ := schema["properties"].(map[string]interface{})
+		m := make(map[string]interface{})
+		for key, item := range v.mapping {
+			keyStr, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("ERROR: YAML key %v is not a string", key)
+			}
+			property, _ := properties[keyStr].(map[string]interface{})
+			converted, err := item.toJson(property, root)
+			if err != nil {
+				return nil, err
+			}
+			m[keyStr] = converted
+		}
+		return m, nil
+	case v.sequence != nil:
+		items, _ := schema["items"].(map[string]interface{})
+		list := make([]interface{}, len(v.sequence))
+		for i, item := range v.sequence {
+			converted, err := item.toJson(items, root)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = converted
+		}
+		return list, nil
+	}
+
+	if _, isString := v.scalar.(string); v.scalar != nil && !isString && schema["type"] == "string" {
+		return v.text, nil
+	}
+	return v.scalar, nil
+}
+
+//resolveSchemaRef returns the definition a schema's local $ref (#/definitions/name) refers to
+func resolveSchemaRef(schema, root map[string]interface{}) map[string]interface{} {
+	ref, ok := schema["$ref"].(string)
+	if !ok || !strings.HasPrefix(ref, "#/definitions/") {
+		return schema
+	}
+	definitions, _ := root["definitions"].(map[string]interface{})
+	definition, _ := definitions[strings.TrimPrefix(ref, "#/definitions/")].(map[string]interface{})
+	return definition
+}
@@ -0,0 +1,38 @@
+package util
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestYamlToJson(t *testing.T) {
+	cases := []struct {
+		yaml   string
+		json   string
+		errStr string
+	}{
+		{"seedVersion: 1.0.0\njob:\n  name: my-job # comment\n  timeout: 10\n", `{"job":{"name":"my-job","timeout":10},"seedVersion":"1.0.0"}`, ""},
+		{"files:\n  - name: A\n    multiple: true\n  - {name: B, mediaTypes: [image/tiff]}\n", `{"files":[{"multiple":true,"name":"A"},{"mediaTypes":["image/tiff"],"name":"B"}]}`, ""},
+		{"command: \"$X ${OUTPUT_DIR}\"\n", `{"command":"$X ${OUTPUT_DIR}"}`, ""},
+		{"seedVersion: 1.0.0\njob:\n  name: on\n  jobVersion: 1.0\n  packageVersion: 1.10\n  title: 2019-01-01\n  timeout: 3600\n",
+			`{"job":{"jobVersion":"1.0","name":"on","packageVersion":"1.10","timeout":3600,"title":"2019-01-01"},"seedVersion":"1.0.0"}`, ""},
+		{"job:\n  interface:\n    settings:\n      - {name: yes, secret: true}\n  errors:\n    - {code: 1, name: 010, title: null}\n",
+			`{"job":{"errors":[{"code":1,"name":"010","title":null}],"interface":{"settings":[{"name":"yes","secret":true}]}}}`, ""},
+		{"unknown: 1.10\n", `{"unknown":1.1}`, ""},
+		{"1: one\n", "", "YAML key 1 is not a string"},
+		{"job: [unclosed\n", "", "yaml:"},
+	}
+
+	for _, c := range cases {
+		out, err := YamlToJson([]byte(c.yaml))
+		if string(out) != c.json {
+			t.Errorf("YamlToJson(%q) returned %s, expected %s", c.yaml, out, c.json)
+		}
+		if err == nil && c.errStr != "" {
+			t.Errorf("YamlToJson(%q) did not return an error when one was expected: %v", c.yaml, c.errStr)
+		}
+		if err != nil && (c.errStr == "" || !strings.Contains(err.Error(), c.errStr)) {
+			t.Errorf("YamlToJson(%q) returned an error: %v\n expected %v", c.yaml, err, c.errStr)
+		}
+	}
+}